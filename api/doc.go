@@ -0,0 +1,8 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package api holds the gRPC service definition served by `srtran server`
+// and the Go code generated from it.
+package api
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative srtran.proto
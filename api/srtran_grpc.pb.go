@@ -0,0 +1,135 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: srtran.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SRTranService_Translate_FullMethodName = "/srtran.v1.SRTranService/Translate"
+)
+
+// SRTranServiceClient is the client API for SRTranService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SRTranService translates subtitle files
+type SRTranServiceClient interface {
+	// Translate translates an SRT file and streams back one subtitle block
+	// at a time
+	Translate(ctx context.Context, in *TranslateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslateResponse], error)
+}
+
+type sRTranServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSRTranServiceClient(cc grpc.ClientConnInterface) SRTranServiceClient {
+	return &sRTranServiceClient{cc}
+}
+
+func (c *sRTranServiceClient) Translate(ctx context.Context, in *TranslateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SRTranService_ServiceDesc.Streams[0], SRTranService_Translate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TranslateRequest, TranslateResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SRTranService_TranslateClient = grpc.ServerStreamingClient[TranslateResponse]
+
+// SRTranServiceServer is the server API for SRTranService service.
+// All implementations must embed UnimplementedSRTranServiceServer
+// for forward compatibility.
+//
+// SRTranService translates subtitle files
+type SRTranServiceServer interface {
+	// Translate translates an SRT file and streams back one subtitle block
+	// at a time
+	Translate(*TranslateRequest, grpc.ServerStreamingServer[TranslateResponse]) error
+	mustEmbedUnimplementedSRTranServiceServer()
+}
+
+// UnimplementedSRTranServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSRTranServiceServer struct{}
+
+func (UnimplementedSRTranServiceServer) Translate(*TranslateRequest, grpc.ServerStreamingServer[TranslateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Translate not implemented")
+}
+func (UnimplementedSRTranServiceServer) mustEmbedUnimplementedSRTranServiceServer() {}
+func (UnimplementedSRTranServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSRTranServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SRTranServiceServer will
+// result in compilation errors.
+type UnsafeSRTranServiceServer interface {
+	mustEmbedUnimplementedSRTranServiceServer()
+}
+
+func RegisterSRTranServiceServer(s grpc.ServiceRegistrar, srv SRTranServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSRTranServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SRTranService_ServiceDesc, srv)
+}
+
+func _SRTranService_Translate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TranslateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SRTranServiceServer).Translate(m, &grpc.GenericServerStream[TranslateRequest, TranslateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SRTranService_TranslateServer = grpc.ServerStreamingServer[TranslateResponse]
+
+// SRTranService_ServiceDesc is the grpc.ServiceDesc for SRTranService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SRTranService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "srtran.v1.SRTranService",
+	HandlerType: (*SRTranServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Translate",
+			Handler:       _SRTranService_Translate_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "srtran.proto",
+}
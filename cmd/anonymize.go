@@ -0,0 +1,67 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/anonymize"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var mapFile string
+
+var anonymizeCmd = &cobra.Command{
+	Use:   "anonymize",
+	Short: "Replace proper nouns with placeholders before translating",
+	Long: `Replace names and places with PERSON_N and PLACE_N placeholders so the
+translation model never sees them. Capitalized multi-word sequences are
+treated as proper nouns. The placeholder mapping is written as JSON for
+use with deanonymize.
+
+Example:
+  srtran anonymize -i movie.srt -o anon.srt --map-output mapping.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+		if mapFile == "" {
+			return fmt.Errorf("mapping output file is required")
+		}
+
+		parser := srt.NewParser(verbose)
+
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		anonymized, mapping := anonymize.Anonymize(subtitles)
+
+		if err := anonymize.SaveMapping(mapFile, mapping); err != nil {
+			return err
+		}
+
+		if err := srt.NewWriter(verbose).Write(outputFile, anonymized); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Replaced %d proper nouns in %s\n", len(mapping), outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	anonymizeCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	anonymizeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	anonymizeCmd.Flags().StringVar(&mapFile, "map-output", "", "file to write the placeholder mapping to (JSON)")
+
+	rootCmd.AddCommand(anonymizeCmd)
+}
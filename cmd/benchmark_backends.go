@@ -0,0 +1,261 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchmarkBackends  []string
+	benchmarkOutputDir string
+	// benchmarkModels maps each backend to its --model-<backend> flag
+	benchmarkModels = make(map[translate.Backend]*string)
+)
+
+// benchmarkBackendNames lists the backends that can be benchmarked, in
+// the order their --model flags are registered
+var benchmarkBackendNames = []translate.Backend{
+	translate.BackendOpenAI,
+	translate.BackendGoogleAI,
+	translate.BackendOpenRouter,
+	translate.BackendVertexAI,
+	translate.BackendLMStudio,
+}
+
+// backendAPIKeyEnv is the environment variable holding each backend's API
+// key, used for backends other than the one in the config file
+var backendAPIKeyEnv = map[translate.Backend]string{
+	translate.BackendOpenAI:     "OPENAI_API_KEY",
+	translate.BackendGoogleAI:   "GOOGLE_AI_API_KEY",
+	translate.BackendOpenRouter: "OPENROUTER_API_KEY",
+	translate.BackendLMStudio:   "LMSTUDIO_API_KEY",
+}
+
+// benchmarkRun is the result of translating the sample with one backend
+type benchmarkRun struct {
+	Backend    translate.Backend
+	Model      string
+	Output     string
+	Elapsed    time.Duration
+	Cost       float64
+	HasCost    bool
+	Translated []srt.Subtitle
+}
+
+var benchmarkBackendsCmd = &cobra.Command{
+	Use:   "benchmark-backends",
+	Short: "Compare translations of the same file across backends",
+	Long: `Translate the same subtitle file with several backends, write each
+translation to <name>.<backend>.<target-language>.srt and print a table of
+the time taken, the estimated cost and how closely each backend agrees with
+the others (mean Levenshtein similarity of the translated subtitles).
+
+Settings other than the backend and model come from the config file. The
+config file's api_key is used for its own backend; other backends read
+their key from OPENAI_API_KEY, GOOGLE_AI_API_KEY, OPENROUTER_API_KEY or
+LMSTUDIO_API_KEY. Costs use the [model_costs] prices and are estimates.
+
+Example:
+  srtran benchmark-backends -i sample.srt -s en -t de --backends openai,googleai,openrouter \
+    --model-openai gpt-4o-mini --model-googleai gemini-2.0-flash --model-openrouter anthropic/claude-3.5-haiku`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if sourceLanguage == "" || targetLanguage == "" {
+			return fmt.Errorf("source and target languages are required")
+		}
+		if len(benchmarkBackends) < 2 {
+			return fmt.Errorf("at least two backends are required")
+		}
+
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		dir := benchmarkOutputDir
+		if dir == "" {
+			dir = filepath.Dir(inputFile)
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		name := strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
+
+		log := newLogger()
+		var runs []benchmarkRun
+		for _, backend := range benchmarkBackends {
+			run, err := benchmarkBackend(cmd, cfg, translate.Backend(backend), subtitles)
+			if err != nil {
+				log.Warn().Err(err).Str("backend", backend).Msg("backend failed")
+				continue
+			}
+
+			run.Output = filepath.Join(dir, name+"."+backend+"."+targetLanguage+".srt")
+			if err := srt.NewWriter(verbose).Write(run.Output, run.Translated); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			runs = append(runs, run)
+		}
+		if len(runs) == 0 {
+			return fmt.Errorf("all backends failed")
+		}
+
+		return printBenchmark(runs)
+	},
+}
+
+// benchmarkBackend translates subtitles with backend and records the time
+// taken and estimated cost
+func benchmarkBackend(cmd *cobra.Command, cfg *config.Config, backend translate.Backend, subtitles []srt.Subtitle) (benchmarkRun, error) {
+	model, ok := benchmarkModels[backend]
+	if !ok {
+		return benchmarkRun{}, fmt.Errorf("unknown backend: %s", backend)
+	}
+
+	backendCfg := *cfg
+	backendCfg.Backend = string(backend)
+	backendCfg.ModelFallback = nil
+	if *model != "" {
+		backendCfg.Model = *model
+	} else if backend != translate.Backend(cfg.Backend) {
+		return benchmarkRun{}, fmt.Errorf("--model-%s is required", backend)
+	}
+	if backend != translate.Backend(cfg.Backend) {
+		backendCfg.APIKey = os.Getenv(backendAPIKeyEnv[backend])
+	}
+
+	config, err := newServiceConfig(&backendCfg)
+	if err != nil {
+		return benchmarkRun{}, err
+	}
+	service, err := translate.NewService(config)
+	if err != nil {
+		return benchmarkRun{}, fmt.Errorf("failed to initialize translation service: %w", err)
+	}
+	defer service.Close()
+
+	// translate a copy so every backend starts from the original text
+	input := make([]srt.Subtitle, len(subtitles))
+	copy(input, subtitles)
+
+	start := time.Now()
+	translated, err := service.Translate(cmd.Context(), input, sourceLanguage, targetLanguage)
+	if err != nil {
+		return benchmarkRun{}, fmt.Errorf("failed to translate: %w", err)
+	}
+
+	run := benchmarkRun{
+		Backend:    backend,
+		Model:      backendCfg.Model,
+		Elapsed:    time.Since(start),
+		Translated: translated,
+	}
+	if cost, ok := cfg.ModelCosts[backendCfg.Model]; ok {
+		usage := translate.EstimateUsage(subtitles, sourceLanguage, targetLanguage, backendCfg.BatchSize, translate.TokenizerForModel(backendCfg.Model))
+		run.Cost = float64(usage.InputTokens)/1e6*cost.Input + float64(usage.OutputTokens)/1e6*cost.Output
+		run.HasCost = true
+	}
+	return run, nil
+}
+
+// printBenchmark prints the comparison table followed by the similarity of
+// each pair of backends
+func printBenchmark(runs []benchmarkRun) error {
+	// similarity[i][j] is the mean similarity of runs i and j
+	similarity := make([][]float64, len(runs))
+	for i := range runs {
+		similarity[i] = make([]float64, len(runs))
+		for j := range runs {
+			if j < i {
+				similarity[i][j] = similarity[j][i]
+			} else if j > i {
+				similarity[i][j] = meanSimilarity(runs[i].Translated, runs[j].Translated)
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BACKEND\tMODEL\tTIME\tEST. COST\tAGREEMENT\tOUTPUT")
+	for i, run := range runs {
+		cost := "n/a"
+		if run.HasCost {
+			cost = fmt.Sprintf("$%.4f", run.Cost)
+		}
+		agreement := "n/a"
+		if len(runs) > 1 {
+			var sum float64
+			for j := range runs {
+				if j != i {
+					sum += similarity[i][j]
+				}
+			}
+			agreement = fmt.Sprintf("%.1f%%", sum/float64(len(runs)-1)*100)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			run.Backend, run.Model, run.Elapsed.Round(time.Millisecond), cost, agreement, run.Output)
+	}
+	if len(runs) > 2 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "BACKEND\tBACKEND\tSIMILARITY")
+		for i := range runs {
+			for j := i + 1; j < len(runs); j++ {
+				fmt.Fprintf(w, "%s\t%s\t%.1f%%\n", runs[i].Backend, runs[j].Backend, similarity[i][j]*100)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// meanSimilarity returns the mean similarity of the translated text of
+// subtitles at the same position in a and b
+func meanSimilarity(a, b []srt.Subtitle) float64 {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += translate.Similarity(strings.Join(a[i].Translated, "\n"), strings.Join(b[i].Translated, "\n"))
+	}
+	return sum / float64(n)
+}
+
+func init() {
+	benchmarkBackendsCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	benchmarkBackendsCmd.Flags().StringVarP(&sourceLanguage, "source", "s", "", "source language")
+	benchmarkBackendsCmd.Flags().StringVarP(&targetLanguage, "target", "t", "", "target language")
+	benchmarkBackendsCmd.Flags().StringSliceVar(&benchmarkBackends, "backends", nil, "comma-separated backends to compare")
+	benchmarkBackendsCmd.Flags().StringVar(&benchmarkOutputDir, "output-dir", "", "directory for the translations (default: next to the input)")
+	for _, backend := range benchmarkBackendNames {
+		model := new(string)
+		benchmarkModels[backend] = model
+		benchmarkBackendsCmd.Flags().StringVar(model, "model-"+string(backend), "", fmt.Sprintf("model for the %s backend (default: from the config file if it is the configured backend)", backend))
+	}
+	for _, name := range []string{"source", "target"} {
+		if err := benchmarkBackendsCmd.RegisterFlagCompletionFunc(name, completeLanguages); err != nil {
+			panic(err)
+		}
+	}
+
+	rootCmd.AddCommand(benchmarkBackendsCmd)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/cache"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect the translation cache",
+	Long: `Inspect the translation cache used by translate --cache.
+
+Example:
+  srtran cache stats`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Print translation cache statistics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := cache.DefaultPath()
+		if err != nil {
+			return err
+		}
+
+		c, err := cache.Open(path, 0)
+		if err != nil {
+			return err
+		}
+
+		lookups := c.Hits + c.Misses
+		hitRate, missRate := 0.0, 0.0
+		if lookups > 0 {
+			hitRate = float64(c.Hits) / float64(lookups) * 100
+			missRate = float64(c.Misses) / float64(lookups) * 100
+		}
+
+		fmt.Printf("Cache file: %s\n", path)
+		fmt.Printf("Entries:    %d\n", len(c.Entries))
+		fmt.Printf("Hit rate:   %.1f%% (%d of %d lookups)\n", hitRate, c.Hits, lookups)
+		fmt.Printf("Miss rate:  %.1f%% (%d of %d lookups)\n", missRate, c.Misses, lookups)
+		fmt.Println("Entries by age:")
+		for _, bucket := range c.AgeBuckets() {
+			fmt.Printf("  %-7s %d\n", bucket.Label, bucket.Count)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatsCmd)
+
+	rootCmd.AddCommand(cacheCmd)
+}
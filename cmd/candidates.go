@@ -0,0 +1,53 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+var (
+	// candidateMu serializes prompts from files translated in parallel
+	candidateMu    sync.Mutex
+	candidateInput = bufio.NewReader(os.Stdin)
+)
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// chooseCandidate shows the candidate translations of sub and asks the user
+// to pick one. An empty or invalid answer picks the first.
+func chooseCandidate(sub srt.Subtitle, candidates [][]string) int {
+	candidateMu.Lock()
+	defer candidateMu.Unlock()
+
+	fmt.Fprintf(logOutput, "\nSubtitle %d (%s --> %s):\n", sub.Index, sub.Start, sub.End)
+	for _, line := range sub.Text {
+		fmt.Fprintf(logOutput, "    %s\n", line)
+	}
+	for i, candidate := range candidates {
+		fmt.Fprintf(logOutput, "  %d) %s\n", i+1, strings.Join(candidate, "\n     "))
+	}
+	fmt.Fprintf(logOutput, "Choose [1-%d, default 1]: ", len(candidates))
+
+	answer, err := candidateInput.ReadString('\n')
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 || n > len(candidates) {
+		return 0
+	}
+	return n - 1
+}
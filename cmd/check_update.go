@@ -0,0 +1,99 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/update"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateTimeout time.Duration
+	noUpdateCheck bool
+
+	// updateNotice receives a one-line notice from the background update check
+	updateNotice = make(chan string, 1)
+)
+
+var checkUpdateCmd = &cobra.Command{
+	Use:   "check-update",
+	Short: "Check GitHub for a newer version of SRTran",
+	Long: `Check the latest SRTran release on GitHub and report whether an upgrade is available.
+
+Example:
+  srtran check-update --timeout 10s`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), updateTimeout)
+		defer cancel()
+
+		release, err := update.LatestRelease(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for updates: %w", err)
+		}
+
+		newer, err := update.IsNewer(release.TagName, Version)
+		if err != nil {
+			fmt.Printf("Latest release is %s (running %s, unable to compare)\n", release.TagName, Version)
+			return nil
+		}
+
+		if !newer {
+			fmt.Printf("SRTran %s is up to date\n", Version)
+			return nil
+		}
+
+		fmt.Printf("A newer version of SRTran is available: %s (running %s)\n", release.TagName, Version)
+		if release.HTMLURL != "" {
+			fmt.Printf("Download: %s\n", release.HTMLURL)
+		}
+		return nil
+	},
+}
+
+// startUpdateCheck runs a background update check unless it is disabled
+func startUpdateCheck(cmd *cobra.Command) {
+	if noUpdateCheck || cmd == checkUpdateCmd {
+		return
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil || !cfg.UpdateCheck {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		release, err := update.LatestRelease(ctx)
+		if err != nil {
+			return
+		}
+
+		if newer, err := update.IsNewer(release.TagName, Version); err == nil && newer {
+			updateNotice <- fmt.Sprintf("A newer version of SRTran is available: %s (running %s). Run 'srtran check-update' for details.", release.TagName, Version)
+		}
+	}()
+}
+
+// printUpdateNotice prints the update notice if the background check has finished
+func printUpdateNotice() {
+	select {
+	case notice := <-updateNotice:
+		fmt.Fprintln(os.Stderr, notice)
+	default:
+	}
+}
+
+func init() {
+	checkUpdateCmd.Flags().DurationVar(&updateTimeout, "timeout", 5*time.Second, "timeout for the GitHub API request")
+
+	rootCmd.AddCommand(checkUpdateCmd)
+}
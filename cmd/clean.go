@@ -0,0 +1,61 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var stripSourceAnnotation bool
+
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Clean up subtitle files",
+	Long: `Clean up subtitle files produced by SRTran.
+
+Example:
+  srtran clean -i reviewed.srt -o final.srt --strip-source-annotation`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+		if !stripSourceAnnotation {
+			return fmt.Errorf("no cleaning option selected")
+		}
+
+		parser := srt.NewParser(verbose)
+
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		if stripSourceAnnotation {
+			subtitles = srt.StripSourceAnnotations(subtitles)
+		}
+
+		if err := srt.NewWriter(verbose).Write(outputFile, subtitles); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Cleaned %s to %s\n", inputFile, outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cleanCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	cleanCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	cleanCmd.Flags().BoolVar(&stripSourceAnnotation, "strip-source-annotation", false, "remove '# Original:' lines written by --annotate-source")
+
+	rootCmd.AddCommand(cleanCmd)
+}
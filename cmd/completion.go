@@ -0,0 +1,68 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/s0up4200/SRTran/internal/lang"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a shell completion script for srtran.
+
+Bash:
+  source <(srtran completion bash)
+
+Zsh:
+  srtran completion zsh > "${fpath[1]}/_srtran"
+
+Fish:
+  srtran completion fish > ~/.config/fish/completions/srtran.fish
+
+PowerShell:
+  srtran completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return fmt.Errorf("unsupported shell: %s", args[0])
+	},
+}
+
+// completeLanguages completes language names and codes for language flags
+func completeLanguages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return lang.Completions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerLanguageCompletion adds language completion to whichever of
+// the --source-language and --target-language flags cmd has
+func registerLanguageCompletion(cmd *cobra.Command) {
+	for _, name := range []string{"source-language", "target-language"} {
+		if cmd.Flags().Lookup(name) == nil {
+			continue
+		}
+		if err := cmd.RegisterFlagCompletionFunc(name, completeLanguages); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the config file",
+	Long: `Manage the SRTran config file.
+
+Example:
+  srtran config validate --config config.toml`,
+}
+
+var (
+	configShowFormat  string
+	configShowSecrets bool
+)
+
+// maskedSecret replaces secret values printed by config show
+const maskedSecret = "****"
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective config",
+	Long: `Print the config SRTran uses after loading the config file and applying
+environment variables, as TOML or JSON. The API key is masked unless
+--show-secrets is given.
+
+Example:
+  srtran config show --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configShowFormat != "toml" && configShowFormat != "json" {
+			return fmt.Errorf("unknown format: %s (expected toml or json)", configShowFormat)
+		}
+
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if !configShowSecrets && cfg.APIKey != "" {
+			cfg.APIKey = maskedSecret
+		}
+
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		if configShowFormat == "toml" {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+
+		// decode the TOML again so JSON uses the same keys as the config file
+		var values map[string]interface{}
+		if _, err := toml.Decode(buf.String(), &values); err != nil {
+			return fmt.Errorf("failed to convert config: %w", err)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(values)
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for errors",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			fmt.Println(err)
+			return fmt.Errorf("config is invalid")
+		}
+
+		problems := validateConfig(cfg)
+		if len(problems) == 0 {
+			fmt.Println("Config is valid.")
+			return nil
+		}
+
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+		return fmt.Errorf("config has %d error(s)", len(problems))
+	},
+}
+
+// validateConfig returns a description of each problem found in cfg
+func validateConfig(cfg *config.Config) []string {
+	var problems []string
+
+	backend := translate.Backend(cfg.Backend)
+	switch backend {
+	case translate.BackendOpenAI, translate.BackendOpenRouter, translate.BackendGoogleAI, translate.BackendLMStudio, translate.BackendVertexAI:
+	case "":
+		problems = append(problems, "backend is not set")
+	default:
+		problems = append(problems, fmt.Sprintf("backend %q is not one of openai, openrouter, googleai, lmstudio, vertexai", cfg.Backend))
+	}
+
+	if cfg.RPM < 0 {
+		problems = append(problems, fmt.Sprintf("rpm must not be negative, got %d", cfg.RPM))
+	}
+
+	switch backend {
+	case translate.BackendOpenAI, translate.BackendOpenRouter, translate.BackendGoogleAI:
+		if cfg.APIKey == "" {
+			problems = append(problems, fmt.Sprintf("api_key is required for %s backend", backend))
+		}
+	case translate.BackendVertexAI:
+		if cfg.ProjectID == "" {
+			problems = append(problems, fmt.Sprintf("project_id is required for %s backend", backend))
+		}
+	}
+
+	// base_url is optional; LM Studio falls back to its local default
+	if cfg.BaseURL != "" {
+		if u, err := url.Parse(cfg.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("base_url %q is not a valid http(s) URL", cfg.BaseURL))
+		}
+	}
+
+	models := make([]string, 0, len(cfg.ModelCosts))
+	for model := range cfg.ModelCosts {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+	for _, model := range models {
+		cost := cfg.ModelCosts[model]
+		for _, price := range []struct {
+			name  string
+			value float64
+		}{{"input", cost.Input}, {"output", cost.Output}} {
+			if price.value < 0 || math.IsNaN(price.value) || math.IsInf(price.value, 0) {
+				problems = append(problems, fmt.Sprintf("model_costs.%s.%s must be a non-negative number, got %v", model, price.name, price.value))
+			}
+		}
+	}
+
+	return problems
+}
+
+func init() {
+	configShowCmd.Flags().StringVar(&configShowFormat, "format", "toml", "output format: toml or json")
+	configShowCmd.Flags().BoolVar(&configShowSecrets, "show-secrets", false, "print the API key instead of masking it")
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	rootCmd.AddCommand(configCmd)
+}
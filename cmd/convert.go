@@ -0,0 +1,108 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	injectStyles      string
+	toPlainText       bool
+	includeTimestamps bool
+)
+
+// subtitleWriter writes subtitles in an output format
+type subtitleWriter interface {
+	Write(w io.Writer, subtitles []srt.Subtitle) error
+}
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert subtitle files to other formats",
+	Long: `Convert SRT subtitle files to other formats. The output format is
+chosen from the output file extension, or is plain text with --to-plain-text.
+
+Supported formats: .ass, .txt
+
+Plain text has one paragraph per subtitle, without formatting tags, and
+can be used to read subtitles as a script or to build a text corpus.
+
+Example:
+  srtran convert -i movie.srt -o movie.ass --inject-styles template.ass
+  srtran convert -i movie.srt -o script.txt --to-plain-text --include-timestamps`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		ext := strings.ToLower(filepath.Ext(outputFile))
+		if toPlainText {
+			ext = ".txt"
+		}
+		if ext != ".ass" && ext != ".txt" {
+			return fmt.Errorf("unsupported output format: %s", ext)
+		}
+		if includeTimestamps && ext != ".txt" {
+			return fmt.Errorf("--include-timestamps requires plain text output")
+		}
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		var writer subtitleWriter
+		switch ext {
+		case ".txt":
+			writer = &srt.TextWriter{IncludeTimestamps: includeTimestamps}
+		default:
+			assWriter := &srt.ASSWriter{}
+			if injectStyles != "" {
+				styles, err := srt.ReadASSStyles(injectStyles)
+				if err != nil {
+					return fmt.Errorf("failed to read styles: %w", err)
+				}
+				assWriter.StylesSection = styles
+			}
+			writer = assWriter
+		}
+
+		file, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		if err := writer.Write(file, subtitles); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Converted %d subtitles to %s\n", len(subtitles), outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	convertCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	convertCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file")
+	convertCmd.Flags().BoolVar(&toPlainText, "to-plain-text", false, "write the subtitle text as plain text, one paragraph per subtitle")
+	convertCmd.Flags().BoolVar(&includeTimestamps, "include-timestamps", false, "prefix each paragraph of plain text output with its start time as [HH:MM:SS]")
+	convertCmd.Flags().StringVar(&injectStyles, "inject-styles", "", "ASS file whose [V4+ Styles] section is used in the output")
+
+	rootCmd.AddCommand(convertCmd)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+var costEstimateCmd = &cobra.Command{
+	Use:   "cost-estimate",
+	Short: "Estimate the cost of translating a subtitle file",
+	Long: `Estimate the tokens and cost of translating a subtitle file with the
+configured model. Prices are read from the [model_costs] section of the
+config file, in USD per million tokens:
+
+  [model_costs."gpt-4o"]
+  input = 2.50
+  output = 10.00
+
+Token counts are estimates.
+
+Example:
+  srtran cost-estimate -i movie.srt -c config.toml --batch-size 10`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		size := cfg.BatchSize
+		if batchSize > 0 {
+			size = batchSize
+		}
+
+		source, target := sourceLanguage, targetLanguage
+		if source == "" {
+			source = "the source language"
+		}
+		if target == "" {
+			target = "the target language"
+		}
+
+		usage := translate.EstimateUsage(subtitles, source, target, size, translate.TokenizerForModel(cfg.Model))
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MODEL\tINPUT TOKENS\tOUTPUT TOKENS\tINPUT COST\tOUTPUT COST\tTOTAL")
+
+		cost, ok := cfg.ModelCosts[cfg.Model]
+		if !ok {
+			fmt.Fprintf(w, "%s\t%d\t%d\tn/a\tn/a\tn/a\n", cfg.Model, usage.InputTokens, usage.OutputTokens)
+		} else {
+			inputCost := float64(usage.InputTokens) / 1e6 * cost.Input
+			outputCost := float64(usage.OutputTokens) / 1e6 * cost.Output
+			fmt.Fprintf(w, "%s\t%d\t%d\t$%.4f\t$%.4f\t$%.4f\n",
+				cfg.Model, usage.InputTokens, usage.OutputTokens, inputCost, outputCost, inputCost+outputCost)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if !ok {
+			log := newLogger()
+			log.Warn().Str("model", cfg.Model).Msg("no price for model in [model_costs]; showing token counts only")
+		}
+		return nil
+	},
+}
+
+func init() {
+	costEstimateCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	costEstimateCmd.Flags().StringVarP(&sourceLanguage, "source-language", "s", "", "source language (optional)")
+	costEstimateCmd.Flags().StringVarP(&targetLanguage, "target-language", "t", "", "target language (optional)")
+	costEstimateCmd.Flags().IntVar(&batchSize, "batch-size", 0, "number of subtitles per request to simulate (default 20)")
+	registerLanguageCompletion(costEstimateCmd)
+
+	rootCmd.AddCommand(costEstimateCmd)
+}
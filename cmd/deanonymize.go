@@ -0,0 +1,62 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/anonymize"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var deanonymizeCmd = &cobra.Command{
+	Use:   "deanonymize",
+	Short: "Restore proper nouns replaced by anonymize",
+	Long: `Replace PERSON_N and PLACE_N placeholders with the original text
+using the mapping written by anonymize.
+
+Example:
+  srtran deanonymize -i translated_anon.srt -o translated.srt --map mapping.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+		if mapFile == "" {
+			return fmt.Errorf("mapping file is required")
+		}
+
+		mapping, err := anonymize.LoadMapping(mapFile)
+		if err != nil {
+			return err
+		}
+
+		parser := srt.NewParser(verbose)
+
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		if err := srt.NewWriter(verbose).Write(outputFile, anonymize.Deanonymize(subtitles, mapping)); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Restored %s to %s\n", inputFile, outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	deanonymizeCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	deanonymizeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	deanonymizeCmd.Flags().StringVar(&mapFile, "map", "", "placeholder mapping written by anonymize")
+
+	rootCmd.AddCommand(deanonymizeCmd)
+}
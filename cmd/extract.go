@@ -0,0 +1,94 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/whisper"
+	"github.com/spf13/cobra"
+)
+
+var extractLanguage string
+
+var extractCmd = &cobra.Command{
+	Use:   "extract-audio",
+	Short: "Generate subtitles from a media file using Whisper",
+	Long: `Extract the audio track from a media file with ffmpeg and transcribe it
+to an SRT file using the OpenAI Whisper API. The result can then be translated
+with 'srtran translate'.
+
+Long recordings are split into 30 minute segments to stay under the Whisper
+upload limit, and the timestamps of each segment are shifted to match the input.
+
+Requires ffmpeg in PATH and an OpenAI API key (the openai backend of --config,
+OPENAI_API_KEY or the openai backend of the default config, in that order).
+
+Example:
+  srtran extract-audio -i movie.mp4 -o movie.srt --language en`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		// a config file passed with --config takes precedence over
+		// OPENAI_API_KEY, which takes precedence over the default config
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" || configFile != "" {
+			cfg, err := config.LoadConfig(configFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if cfg.Backend == "openai" && cfg.APIKey != "" {
+				apiKey = cfg.APIKey
+			}
+		}
+
+		transcriber, err := whisper.NewTranscriber(apiKey)
+		if err != nil {
+			return err
+		}
+
+		if verbose {
+			fmt.Printf("Extracting audio from %s\n", inputFile)
+		}
+
+		audio, err := whisper.ExtractAudio(cmd.Context(), inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to extract audio: %w", err)
+		}
+		defer audio.Remove()
+
+		if verbose {
+			fmt.Printf("Transcribing %d audio segment(s) with Whisper\n", len(audio.Segments))
+		}
+
+		transcript, err := transcriber.Transcribe(cmd.Context(), audio, extractLanguage)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(outputFile, []byte(transcript), 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Wrote subtitles to %s\n", outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	extractCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input media file")
+	extractCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	extractCmd.Flags().StringVarP(&extractLanguage, "language", "l", "", "spoken language as an ISO-639-1 code (e.g., 'en'), auto-detected if empty")
+
+	rootCmd.AddCommand(extractCmd)
+}
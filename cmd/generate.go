@@ -0,0 +1,95 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+// generateGap is the pause inserted between generated subtitles
+const generateGap = 100 * time.Millisecond
+
+var durationPerLine time.Duration
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a synthetic subtitle file from a script",
+	Long: `Generate a synthetic subtitle file from a plain-text script.
+
+Each non-empty line of the script becomes one subtitle with sequential timestamps.
+Useful for creating test fixtures with known content.
+
+Example:
+  srtran generate -i script.txt -o test.srt --duration-per-line 3s`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+		if durationPerLine <= 0 {
+			return fmt.Errorf("duration per line must be positive")
+		}
+
+		file, err := os.Open(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer file.Close()
+
+		var subtitles []srt.Subtitle
+		var start time.Duration
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+
+			end := start + durationPerLine
+			subtitles = append(subtitles, srt.Subtitle{
+				Index: len(subtitles) + 1,
+				Start: srt.FormatTimestamp(start),
+				End:   srt.FormatTimestamp(end),
+				Text:  []string{line},
+			})
+			start = end + generateGap
+		}
+
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+
+		if len(subtitles) == 0 {
+			return fmt.Errorf("no non-empty lines found in %s", inputFile)
+		}
+
+		writer := srt.NewWriter(verbose)
+		if err := writer.Write(outputFile, subtitles); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Generated %d subtitles in %s\n", len(subtitles), outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input script file")
+	generateCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	generateCmd.Flags().DurationVar(&durationPerLine, "duration-per-line", 3*time.Second, "display duration of each subtitle")
+
+	rootCmd.AddCommand(generateCmd)
+}
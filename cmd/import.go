@@ -0,0 +1,135 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/lang"
+	"github.com/s0up4200/SRTran/internal/opensubtitles"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importQuery     string
+	importYear      int
+	importLanguage  string
+	importDownload  bool
+	importTranslate bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Search OpenSubtitles.com and download or translate the top result",
+	Long: `Search OpenSubtitles.com for subtitles. Without --download the matching
+subtitles are listed; with --download the top result is saved as
+<name>.<lang>.srt, and with --translate it is then translated to
+<name>.<target-language>.srt using the translate settings from the config.
+
+The API key is read from the OPENSUBTITLES_API_KEY environment variable.
+
+Example:
+  srtran import --query "movie title" --year 2023 --lang en --download --translate --target de`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if importQuery == "" {
+			return fmt.Errorf("--query is required")
+		}
+		if importTranslate && !importDownload {
+			return fmt.Errorf("--translate requires --download")
+		}
+		if importTranslate && targetLanguage == "" {
+			return fmt.Errorf("target language is required")
+		}
+		apiKey := os.Getenv("OPENSUBTITLES_API_KEY")
+		if apiKey == "" {
+			return fmt.Errorf("OPENSUBTITLES_API_KEY environment variable is required")
+		}
+
+		client := opensubtitles.NewClient(apiKey, "SRTran v"+Version)
+		results, err := client.Search(cmd.Context(), opensubtitles.SearchParams{
+			Query:    importQuery,
+			Year:     importYear,
+			Language: importLanguage,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to search OpenSubtitles: %w", err)
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("no subtitles found for %q", importQuery)
+		}
+
+		if !importDownload {
+			for _, r := range results {
+				fmt.Printf("%10d  %-4s %8d  %s\n", r.FileID, r.Language, r.DownloadCount, r.Release)
+			}
+			return nil
+		}
+
+		top := results[0]
+		data, err := client.Download(cmd.Context(), top.FileID)
+		if err != nil {
+			return fmt.Errorf("failed to download subtitle %d: %w", top.FileID, err)
+		}
+
+		language := top.Language
+		if language == "" {
+			language = importLanguage
+		}
+		name := strings.TrimSuffix(importName(top), "."+language)
+		downloaded := name + "." + language + ".srt"
+		if outputFile != "" && !importTranslate {
+			downloaded = outputFile
+		}
+		if err := os.WriteFile(downloaded, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write downloaded subtitle: %w", err)
+		}
+		if verbose {
+			fmt.Printf("Downloaded %s to %s\n", top.Release, downloaded)
+		}
+
+		if !importTranslate {
+			return nil
+		}
+
+		// run the downloaded file through the translate pipeline
+		inputFile = downloaded
+		if outputFile == "" {
+			outputFile = name + "." + targetLanguage + ".srt"
+		}
+		sourceLanguage = language
+		for _, l := range lang.Common {
+			if l.Code == language {
+				sourceLanguage = l.Name
+				break
+			}
+		}
+
+		var result translateResult
+		return runTranslate(cmd, &result)
+	},
+}
+
+// importName returns the base name for files created from a search result
+func importName(s opensubtitles.Subtitle) string {
+	name := strings.TrimSuffix(filepath.Base(s.FileName), filepath.Ext(s.FileName))
+	if name == "" || name == "." {
+		name = fmt.Sprintf("opensubtitles-%d", s.FileID)
+	}
+	return name
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importQuery, "query", "", "title to search for")
+	importCmd.Flags().IntVar(&importYear, "year", 0, "release year to narrow the search")
+	importCmd.Flags().StringVar(&importLanguage, "lang", "en", "ISO 639-1 code of the subtitle language to search for")
+	importCmd.Flags().BoolVar(&importDownload, "download", false, "download the top result")
+	importCmd.Flags().BoolVar(&importTranslate, "translate", false, "translate the downloaded subtitle (requires --download and --target)")
+	importCmd.Flags().StringVarP(&targetLanguage, "target", "t", "", "target language for --translate (e.g., 'german', 'de')")
+	importCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file (default <name>.<lang>.srt, or <name>.<target>.srt with --translate)")
+
+	rootCmd.AddCommand(importCmd)
+}
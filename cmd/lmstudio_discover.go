@@ -0,0 +1,44 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+var lmStudioBaseURL string
+
+var lmStudioDiscoverCmd = &cobra.Command{
+	Use:   "lmstudio-discover",
+	Short: "List the models available on an LM Studio server",
+	Long: `List the IDs of the models available on a running LM Studio server, to use
+as model in the config file. LMSTUDIO_API_KEY is sent if set.
+
+Example:
+  srtran lmstudio-discover --base-url http://localhost:1234/v1`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		models, err := translate.LMStudioModels(cmd.Context(), lmStudioBaseURL, os.Getenv("LMSTUDIO_API_KEY"))
+		if err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return fmt.Errorf("no models available at %s", lmStudioBaseURL)
+		}
+
+		for _, model := range models {
+			fmt.Println(model)
+		}
+		return nil
+	},
+}
+
+func init() {
+	lmStudioDiscoverCmd.Flags().StringVar(&lmStudioBaseURL, "base-url", translate.DefaultLMStudioBaseURL, "LM Studio API endpoint")
+
+	rootCmd.AddCommand(lmStudioDiscoverCmd)
+}
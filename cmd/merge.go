@@ -0,0 +1,79 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeFileA string
+	mergeFileB string
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge two partial translations of a subtitle file",
+	Long: `Merge two translations of the same subtitle file by index. Blocks from
+--a are preferred; blocks that are empty or missing in --a are taken
+from --b. The result is reindexed.
+
+Example:
+  srtran merge --a human.srt --b machine.srt -o merged.srt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if mergeFileA == "" || mergeFileB == "" {
+			return fmt.Errorf("--a and --b are required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		parser := srt.NewParser(verbose)
+		a, err := parseTranslated(parser, mergeFileA)
+		if err != nil {
+			return err
+		}
+		b, err := parseTranslated(parser, mergeFileB)
+		if err != nil {
+			return err
+		}
+
+		merged, err := srt.Merge(a, b)
+		if err != nil {
+			return fmt.Errorf("failed to merge subtitles: %w", err)
+		}
+
+		if err := srt.NewWriter(verbose).Write(outputFile, merged); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Merged %d and %d subtitles into %d in %s\n", len(a), len(b), len(merged), outputFile)
+		}
+		return nil
+	},
+}
+
+// parseTranslated parses a translated file, whose text is the translation
+func parseTranslated(parser *srt.Parser, path string) ([]srt.Subtitle, error) {
+	subtitles, err := parser.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i := range subtitles {
+		subtitles[i].Translated = subtitles[i].Text
+	}
+	return subtitles, nil
+}
+
+func init() {
+	mergeCmd.Flags().StringVar(&mergeFileA, "a", "", "preferred subtitle file, e.g. a human translation")
+	mergeCmd.Flags().StringVar(&mergeFileB, "b", "", "subtitle file used where --a has no text")
+	mergeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+
+	rootCmd.AddCommand(mergeCmd)
+}
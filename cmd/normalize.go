@@ -0,0 +1,147 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+// normalizeStep is a pass of the normalize command that can be disabled
+// with --disable-<name>
+type normalizeStep struct {
+	name        string
+	description string
+}
+
+// normalizeSteps lists the passes in the order they run
+var normalizeSteps = []normalizeStep{
+	{"bom", "remove a UTF-8 byte order mark"},
+	{"line-endings", "write LF line endings"},
+	{"carriage-returns", "remove carriage returns inside lines"},
+	{"whitespace", "trim whitespace around each line"},
+	{"empty", "remove blocks without text"},
+	{"overlaps", "end each block when the next one starts"},
+	{"short", "remove blocks shorter than --min-duration"},
+	{"line-length", "re-wrap lines longer than --max-chars-per-line"},
+	{"reindex", "renumber blocks from 1"},
+}
+
+var (
+	normalizeMinDuration time.Duration
+	normalizeMaxChars    int
+	// normalizeDisabled maps each step name to its --disable flag
+	normalizeDisabled = make(map[string]*bool)
+)
+
+var normalizeCmd = &cobra.Command{
+	Use:   "normalize",
+	Short: "Run all cleanup passes on a subtitle file",
+	Long: `Clean up a subtitle file before translating it. The passes run in this
+order and each can be turned off with --disable-<pass>:
+
+  bom               remove a UTF-8 byte order mark
+  line-endings      write LF line endings
+  carriage-returns  remove carriage returns inside lines
+  whitespace        trim whitespace around each line
+  empty             remove blocks without text
+  overlaps          end each block when the next one starts
+  short             remove blocks shorter than --min-duration
+  line-length       re-wrap lines longer than --max-chars-per-line
+  reindex           renumber blocks from 1
+
+With bom or line-endings disabled, a byte order mark or CRLF line endings
+in the input are kept in the output.
+
+Example:
+  srtran normalize -i messy.srt -o clean.srt --disable-line-length`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		data, err := os.ReadFile(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+
+		parser := srt.NewParser(verbose)
+		parser.KeepCarriageReturns = *normalizeDisabled["carriage-returns"]
+		subtitles, err := parser.ParseReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		log := newLogger()
+		enabled := func(step string) bool { return !*normalizeDisabled[step] }
+
+		if enabled("whitespace") {
+			subtitles = srt.TrimLines(subtitles)
+		}
+		if enabled("empty") {
+			before := len(subtitles)
+			subtitles = srt.RemoveEmpty(subtitles)
+			log.Info().Int("removed", before-len(subtitles)).Msg("removed empty subtitles")
+		}
+		if enabled("overlaps") {
+			var fixed int
+			subtitles, fixed = srt.FixOverlaps(subtitles)
+			log.Info().Int("fixed", fixed).Msg("fixed overlapping subtitles")
+		}
+		if enabled("short") && normalizeMinDuration > 0 {
+			before := len(subtitles)
+			subtitles = srt.RemoveShort(subtitles, normalizeMinDuration)
+			log.Info().
+				Int("removed", before-len(subtitles)).
+				Dur("min_duration", normalizeMinDuration).
+				Msg("removed short subtitles")
+		}
+		if enabled("line-length") && normalizeMaxChars > 0 {
+			for i := range subtitles {
+				subtitles[i].Text = srt.WrapLines(subtitles[i].Text, normalizeMaxChars, srt.WrapGreedy)
+			}
+		}
+		if enabled("reindex") {
+			subtitles = srt.Reindex(subtitles)
+		}
+
+		writer := srt.NewWriter(verbose)
+		if !enabled("bom") && bytes.HasPrefix(data, []byte("\ufeff")) {
+			writer.Encoding = srt.EncodingUTF8BOM
+		}
+		if !enabled("line-endings") && bytes.Contains(data, []byte("\r\n")) {
+			writer.LineEnding = srt.LineEndingCRLF
+		}
+		if err := writer.Write(outputFile, subtitles); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Normalized %s to %s\n", inputFile, outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	normalizeCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	normalizeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	normalizeCmd.Flags().DurationVar(&normalizeMinDuration, "min-duration", 100*time.Millisecond, "minimum duration kept by the short pass")
+	normalizeCmd.Flags().IntVar(&normalizeMaxChars, "max-chars-per-line", 42, "line length limit of the line-length pass")
+	for _, step := range normalizeSteps {
+		disabled := new(bool)
+		normalizeDisabled[step.name] = disabled
+		normalizeCmd.Flags().BoolVar(disabled, "disable-"+step.name, false, "do not "+step.description)
+	}
+
+	rootCmd.AddCommand(normalizeCmd)
+}
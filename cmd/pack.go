@@ -0,0 +1,183 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"archive/zip"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/lang"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	packVideo    string
+	packLanguage string
+)
+
+// packMetadata is written to METADATA.json in archives created by pack
+type packMetadata struct {
+	Subtitle      string            `json:"subtitle"`
+	Video         string            `json:"video"`
+	Language      string            `json:"language,omitempty"`
+	SubtitleCount int               `json:"subtitle_count"`
+	SRTranVersion string            `json:"srtran_version"`
+	PackDate      time.Time         `json:"pack_date"`
+	MD5           map[string]string `json:"md5"`
+}
+
+var packCmd = &cobra.Command{
+	Use:   "pack",
+	Short: "Create a zip archive of a subtitle and its video",
+	Long: `Create a zip archive containing a subtitle file, its video and a
+METADATA.json with the subtitle count, language, SRTran version, pack date
+and MD5 checksums of both files.
+
+The language is taken from --language, or from the subtitle file name
+(e.g. movie.en.srt) when not given.
+
+Example:
+  srtran pack -i movie.srt --video movie.mkv -o archive.zip`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if packVideo == "" {
+			return fmt.Errorf("video file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		parser := srt.NewParser(verbose)
+
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		language := packLanguage
+		if language == "" {
+			if l, ok := lang.FromFilename(inputFile); ok {
+				language = l.Code
+			}
+		}
+
+		metadata := packMetadata{
+			Subtitle:      filepath.Base(inputFile),
+			Video:         filepath.Base(packVideo),
+			Language:      language,
+			SubtitleCount: len(subtitles),
+			SRTranVersion: Version,
+			PackDate:      time.Now().UTC(),
+			MD5:           make(map[string]string),
+		}
+
+		if err := writePack(outputFile, inputFile, packVideo, &metadata); err != nil {
+			return err
+		}
+
+		if verbose {
+			fmt.Printf("Packed %s and %s into %s\n", inputFile, packVideo, outputFile)
+		}
+		return nil
+	},
+}
+
+// writePack creates a zip archive at path with the subtitle, the video and
+// METADATA.json, filling in the checksums of metadata as the files are added
+func writePack(path, subtitle, video string, metadata *packMetadata) (err error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to close archive: %w", closeErr)
+		}
+	}()
+
+	zw := zip.NewWriter(out)
+
+	// videos are already compressed, so store them as is
+	for _, file := range []struct {
+		path   string
+		method uint16
+	}{
+		{subtitle, zip.Deflate},
+		{video, zip.Store},
+	} {
+		sum, err := addToZip(zw, file.path, file.method)
+		if err != nil {
+			return err
+		}
+		metadata.MD5[filepath.Base(file.path)] = sum
+	}
+
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	w, err := zw.Create("METADATA.json")
+	if err != nil {
+		return fmt.Errorf("failed to add metadata: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// addToZip copies the file at path into zw under its base name and
+// returns its MD5 checksum
+func addToZip(zw *zip.Writer, path string, method uint16) (string, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return "", fmt.Errorf("failed to create zip header for %s: %w", path, err)
+	}
+	header.Name = filepath.Base(path)
+	header.Method = method
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to add %s: %w", path, err)
+	}
+
+	hash := md5.New()
+	if _, err := io.Copy(io.MultiWriter(w, hash), in); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func init() {
+	packCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	packCmd.Flags().StringVar(&packVideo, "video", "", "video file to include")
+	packCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output zip archive")
+	packCmd.Flags().StringVar(&packLanguage, "language", "", "subtitle language recorded in the metadata (default: from the file name)")
+
+	rootCmd.AddCommand(packCmd)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/plugin"
+	"github.com/spf13/cobra"
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage prompt plugins",
+	Long: `Install, list and remove prompt plugins.
+
+Plugins are executables in ~/.config/srtran/plugins that read a JSON
+request ({"source_lang", "target_lang", "prompt"}) on stdin and write
+{"prompt": "..."} with the prompt to use on stdout.
+
+Example:
+  srtran plugin install https://github.com/user/srtran-plugin
+  srtran plugin list
+  srtran plugin remove srtran-plugin`,
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <github-url>",
+	Short: "Install a plugin with go install",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := plugin.Install(cmd.Context(), args[0]); err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Printf("Installed %s\n", args[0])
+		}
+		return nil
+	},
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed plugins",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := plugin.List()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	},
+}
+
+var pluginRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an installed plugin",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return plugin.Remove(args[0])
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginInstallCmd, pluginListCmd, pluginRemoveCmd)
+
+	rootCmd.AddCommand(pluginCmd)
+}
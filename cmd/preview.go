@@ -0,0 +1,118 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+// previewCount is the number of subtitles translated from each end of the file
+const previewCount = 5
+
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Translate a few subtitles for a quick quality check",
+	Long: `Translate the first and last 5 subtitles of a file and print them side by side.
+No output file is written. The elapsed time is used to estimate a full run.
+
+Example:
+  srtran preview -i movie.srt -s english -t german`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if targetLanguage == "" {
+			return fmt.Errorf("target language is required")
+		}
+		if sourceLanguage == "" {
+			return fmt.Errorf("source language is required")
+		}
+
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		sample := previewSample(subtitles)
+
+		config, err := newServiceConfig(cfg)
+		if err != nil {
+			return err
+		}
+
+		service, err := translate.NewService(config)
+		if err != nil {
+			return fmt.Errorf("failed to initialize translation service: %w", err)
+		}
+		defer service.Close()
+
+		start := time.Now()
+		translated, err := service.Translate(cmd.Context(), sample, sourceLanguage, targetLanguage)
+		if err != nil {
+			return fmt.Errorf("failed to translate subtitles: %w", err)
+		}
+		elapsed := time.Since(start)
+
+		for _, sub := range translated {
+			fmt.Printf("\n#%d  %s --> %s\n", sub.Index, sub.Start, sub.End)
+			fmt.Printf("  %-12s %s\n", "Original:", strings.Join(sub.Text, " / "))
+			fmt.Printf("  %-12s %s\n", "Translation:", strings.Join(sub.Translated, " / "))
+		}
+
+		// extrapolate from the time taken per batch
+		sampleBatches := batchCount(len(sample), config.BatchSize)
+		perBatch := elapsed / time.Duration(sampleBatches)
+		estimate := perBatch * time.Duration(batchCount(len(subtitles), config.BatchSize))
+
+		fmt.Printf("\nPreviewed %d of %d subtitles in %s\n", len(sample), len(subtitles), elapsed.Round(time.Millisecond))
+		fmt.Printf("Estimated full translation time: %s\n", estimate.Round(time.Second))
+		return nil
+	},
+}
+
+// previewSample returns the first and last previewCount subtitles without duplicates
+func previewSample(subtitles []srt.Subtitle) []srt.Subtitle {
+	if len(subtitles) <= 2*previewCount {
+		return subtitles
+	}
+
+	sample := make([]srt.Subtitle, 0, 2*previewCount)
+	sample = append(sample, subtitles[:previewCount]...)
+	return append(sample, subtitles[len(subtitles)-previewCount:]...)
+}
+
+// batchCount returns the number of batches needed for n subtitles,
+// using the default batch size when size is 0
+func batchCount(n, size int) int {
+	if size <= 0 {
+		size = translate.DefaultBatchSize
+	}
+	count := (n + size - 1) / size
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+func init() {
+	previewCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	previewCmd.Flags().StringVarP(&sourceLanguage, "source-language", "s", "", "source language (e.g., 'english', 'spanish')")
+	previewCmd.Flags().StringVarP(&targetLanguage, "target-language", "t", "", "target language (e.g., 'norwegian', 'german')")
+	registerLanguageCompletion(previewCmd)
+
+	rootCmd.AddCommand(previewCmd)
+}
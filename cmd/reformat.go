@@ -0,0 +1,70 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reformatEllipsis string
+	reformatQuotes   string
+)
+
+var reformatCmd = &cobra.Command{
+	Use:   "reformat",
+	Short: "Normalize whitespace and punctuation in subtitle text",
+	Long: `Normalize subtitle text: collapse runs of spaces, trim each line, remove
+spaces before periods, commas and ellipses ("Hello ." becomes "Hello.")
+and optionally rewrite ellipses and quote marks in a consistent style.
+
+Example:
+  srtran reformat -i messy.srt -o clean.srt --ellipsis-style unicode --quotes curly`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		ellipsis, err := srt.ParseEllipsisStyle(reformatEllipsis)
+		if err != nil {
+			return err
+		}
+		quotes, err := srt.ParseQuoteStyle(reformatQuotes)
+		if err != nil {
+			return err
+		}
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		subtitles = srt.Reformat(subtitles, srt.ReformatOptions{Ellipsis: ellipsis, Quotes: quotes})
+
+		if err := srt.NewWriter(verbose).Write(outputFile, subtitles); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Reformatted %s to %s\n", inputFile, outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reformatCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	reformatCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	reformatCmd.Flags().StringVar(&reformatEllipsis, "ellipsis-style", string(srt.EllipsisKeep), "ellipses: keep, unicode (…) or ascii (...)")
+	reformatCmd.Flags().StringVar(&reformatQuotes, "quotes", string(srt.QuotesKeep), "quote marks: keep, straight or curly")
+
+	rootCmd.AddCommand(reformatCmd)
+}
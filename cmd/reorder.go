@@ -0,0 +1,73 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+// reorderWarnRatio is the share of moved blocks above which reorder warns
+const reorderWarnRatio = 0.1
+
+var reorderCmd = &cobra.Command{
+	Use:   "reorder",
+	Short: "Sort subtitle blocks by start time",
+	Long: `Sort subtitle blocks by start time and renumber them from 1.
+
+Overlapping subtitles after sorting are reported.
+
+Example:
+  srtran reorder -i disordered.srt -o sorted.srt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		log := newLogger()
+		parser := srt.NewParser(verbose)
+
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		sorted, moved, err := srt.SortByStart(subtitles)
+		if err != nil {
+			return fmt.Errorf("failed to sort subtitles: %w", err)
+		}
+
+		if float64(moved) > float64(len(subtitles))*reorderWarnRatio {
+			log.Warn().
+				Int("moved", moved).
+				Int("total", len(subtitles)).
+				Msg("sorting moved more than 10% of blocks; the file may be intentionally non-sequential")
+		}
+
+		for _, index := range srt.FindOverlaps(sorted) {
+			log.Warn().Int("index", index).Msg("subtitle overlaps the previous subtitle")
+		}
+
+		if err := srt.NewWriter(verbose).Write(outputFile, srt.Reindex(sorted)); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Reordered %d of %d subtitles into %s\n", moved, len(subtitles), outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reorderCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	reorderCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+
+	rootCmd.AddCommand(reorderCmd)
+}
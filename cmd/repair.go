@@ -0,0 +1,83 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Fix OCR errors in subtitle files",
+	Long: `Use the configured model to fix OCR errors such as misread characters
+and split words, without translating. Run this before translating subtitles
+ripped from DVDs or other image-based sources.
+
+Example:
+  srtran repair -i ocr.srt -o repaired.srt -s english`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		log := newLogger()
+		log.Info().
+			Str("backend", cfg.Backend).
+			Str("model", cfg.Model).
+			Msg("configuration loaded")
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		config, err := newServiceConfig(cfg)
+		if err != nil {
+			return err
+		}
+
+		service, err := translate.NewService(config)
+		if err != nil {
+			return fmt.Errorf("failed to initialize translation service: %w", err)
+		}
+		defer service.Close()
+
+		repaired, err := service.Repair(cmd.Context(), subtitles, sourceLanguage)
+		if err != nil {
+			return fmt.Errorf("failed to repair subtitles: %w", err)
+		}
+
+		if err := srt.NewWriter(verbose).Write(outputFile, repaired); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Repaired %s to %s\n", inputFile, outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	repairCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	repairCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	repairCmd.Flags().StringVarP(&sourceLanguage, "source-language", "s", "", "language of the subtitles (optional)")
+	registerLanguageCompletion(repairCmd)
+
+	rootCmd.AddCommand(repairCmd)
+}
@@ -4,6 +4,11 @@
 package cmd
 
 import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 )
 
@@ -11,10 +16,126 @@ var (
 	// Flags
 	configFile     string
 	inputFile      string
+	inputDir       string
+	parallelFiles  int
 	outputFile     string
+	outputDir      string
+	outputTemplate string
 	targetLanguage string
 	sourceLanguage string
 	verbose        bool
+	modelFallback  []string
+	chaptersFile   string
+	backup         bool
+	backupSuffix   bool
+	annotateSource bool
+	outputEncoding string
+	promptTemplate string
+	promptPlugin   string
+	batchSize      int
+	failFast       bool
+	noRetry        bool
+	nCandidates    int
+	useCache       bool
+	toneDetection  bool
+	cacheTTL       time.Duration
+	maxTokens      int
+	tokenizerModel string
+
+	// Prompt context flags
+	sourceContextLines int
+	tempByLength       bool
+
+	// Glossary flags
+	glossaryFromPrevious bool
+	previousOriginal     string
+	previousTranslated   string
+
+	// Title flags
+	translateTitle string
+	titleLang      string
+
+	// Debugging flags
+	printPrompt     bool
+	batchIndex      int
+	shuffleValidate bool
+
+	// Error handling flags
+	continueOnError bool
+	requestTimeout  time.Duration
+	timedOutText    string
+
+	// Rate limit flags
+	rateLimitStrategy string
+
+	// Pre-processing flags
+	splitOnSilence  bool
+	audioFile       string
+	lenient         bool
+	minDuration     time.Duration
+	stripMusicNotes bool
+	ignoreEmpty     bool
+	maxTextLines    int
+	splitMerged     bool
+	compressSpace   bool
+
+	// Output flags
+	reportFile          string
+	progressMarkdown    string
+	metadataFile        string
+	prependText         string
+	appendText          string
+	hashCheck           bool
+	hashFile            bool
+	lineEnding          string
+	writeMode           string
+	writeTranslatedOnly bool
+	bilingualSeparator  string
+
+	// Untranslated subtitle flags
+	outputOriginalOnEmpty bool
+
+	// Sound-effect flags
+	keepEffectsTranslated bool
+	keepEffectsOriginal   bool
+
+	// Post-processing flags
+	retranslateThreshold float64
+	maxCharsPerLine      int
+	wordWrapAlgorithm    string
+	lineLimitMode        string
+	twoPass              bool
+	twoPassModel         string
+
+	// Hook flags
+	preTranslateScript  string
+	postTranslateScript string
+
+	// Notification flags
+	webhookURL    string
+	webhookSecret string
+
+	// Telemetry flags
+	telemetryEnabled bool
+
+	// OpenAI flags
+	openAIOrganization string
+	openAIJSONSchema   bool
+
+	// Google flags
+	googleAIRegion          string
+	googleAISafetyThreshold string
+	geminiGrounding         bool
+
+	// OpenRouter flags
+	openRouterSiteURL    string
+	openRouterAppName    string
+	openRouterProviders  []string
+	openRouterTransforms []string
+
+	// LM Studio flags
+	autoModel   bool
+	lmStudioTTL int
 
 	// Root command
 	rootCmd = &cobra.Command{
@@ -25,6 +146,12 @@ from one language to another using various AI translation capabilities.
 
 Example:
   srtran translate -i input.srt -o output.srt -s en -t es`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			startUpdateCheck(cmd)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			printUpdateNotice()
+		},
 	}
 )
 
@@ -33,8 +160,18 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// logOutput receives logs and progress messages. It is switched to
+// stderr when subtitles are written to stdout.
+var logOutput io.Writer = os.Stdout
+
+// newLogger creates the console logger used by commands
+func newLogger() zerolog.Logger {
+	return zerolog.New(zerolog.ConsoleWriter{Out: logOutput}).With().Timestamp().Logger()
+}
+
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "config file path")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false, "disable the startup check for newer versions")
 }
@@ -0,0 +1,133 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+var selftestBackend string
+
+// selftestSRT is the synthetic subtitle file translated by selftest
+const selftestSRT = `1
+00:00:01,000 --> 00:00:03,000
+Hello, World.
+
+2
+00:00:04,000 --> 00:00:06,000
+How are you?
+
+3
+00:00:07,000 --> 00:00:09,000
+Goodbye.
+`
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check the backend with a short test translation",
+	Long: `Translate a three-subtitle English file to Spanish with the configured
+backend and check that the result is a valid subtitle file with three
+non-empty, translated blocks. Use it to verify an API key or backend
+configuration, e.g. in CI.
+
+Example:
+  srtran selftest --backend openai`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if selftestBackend != "" {
+			cfg.Backend = selftestBackend
+		}
+
+		log := newLogger()
+		log.Info().
+			Str("backend", cfg.Backend).
+			Str("model", cfg.Model).
+			Msg("configuration loaded")
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.ParseString(selftestSRT)
+		if err != nil {
+			return fmt.Errorf("failed to parse test subtitles: %w", err)
+		}
+
+		config, err := newServiceConfig(cfg)
+		if err != nil {
+			return err
+		}
+
+		service, err := translate.NewService(config)
+		if err != nil {
+			return fmt.Errorf("failed to initialize translation service: %w", err)
+		}
+		defer service.Close()
+
+		translated, err := service.Translate(cmd.Context(), subtitles, "english", "spanish")
+		if err != nil {
+			return fmt.Errorf("failed to translate test subtitles: %w", err)
+		}
+
+		// write and re-parse the result to check it is a valid SRT file
+		dir, err := os.MkdirTemp("", "srtran-selftest")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "selftest.srt")
+		if err := srt.NewWriter(verbose).Write(path, translated); err != nil {
+			return fmt.Errorf("failed to write translated subtitles: %w", err)
+		}
+		written, err := parser.Parse(path)
+		if err != nil {
+			return fmt.Errorf("translated output is not valid SRT: %w", err)
+		}
+
+		if problems := selftestProblems(subtitles, written); len(problems) > 0 {
+			for _, problem := range problems {
+				fmt.Println(problem)
+			}
+			return fmt.Errorf("selftest failed with %d problem(s)", len(problems))
+		}
+
+		fmt.Println("All checks passed.")
+		return nil
+	},
+}
+
+// selftestProblems compares the original test subtitles with the
+// translated file and describes each check that failed
+func selftestProblems(original, translated []srt.Subtitle) []string {
+	if len(translated) != len(original) {
+		return []string{fmt.Sprintf("expected %d subtitles, got %d", len(original), len(translated))}
+	}
+
+	var problems []string
+	for i, sub := range translated {
+		text := strings.TrimSpace(strings.Join(sub.Text, "\n"))
+		switch {
+		case text == "":
+			problems = append(problems, fmt.Sprintf("subtitle %d is empty", sub.Index))
+		case text == strings.Join(original[i].Text, "\n"):
+			problems = append(problems, fmt.Sprintf("subtitle %d was not translated: %q", sub.Index, text))
+		}
+	}
+	return problems
+}
+
+func init() {
+	selftestCmd.Flags().StringVar(&selftestBackend, "backend", "", "backend to test (default: from the config file)")
+
+	rootCmd.AddCommand(selftestCmd)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/grpc"
+	"github.com/spf13/cobra"
+)
+
+var serverListen string
+
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Serve translations over gRPC",
+	Long: `Serve the SRTranService defined in api/srtran.proto. Clients send an SRT
+file with its languages and get back one subtitle block at a time as each
+batch is translated. Requests use the backend of the config file unless
+they set their own; one that picks another backend or base URL must send
+its own API key.
+
+The server has no authentication, so it listens on localhost by default.
+
+Example:
+  srtran server --listen localhost:50051`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		defaults, err := newServiceConfig(cfg)
+		if err != nil {
+			return err
+		}
+
+		lis, err := net.Listen("tcp", serverListen)
+		if err != nil {
+			return fmt.Errorf("failed to listen: %w", err)
+		}
+
+		log := newLogger()
+		log.Info().
+			Str("address", lis.Addr().String()).
+			Str("backend", cfg.Backend).
+			Str("model", cfg.Model).
+			Msg("serving gRPC")
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		return grpc.NewServer(defaults, log).Serve(ctx, lis)
+	},
+}
+
+func init() {
+	serverCmd.Flags().StringVar(&serverListen, "listen", "localhost:50051", "address to serve gRPC on")
+
+	rootCmd.AddCommand(serverCmd)
+}
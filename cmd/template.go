@@ -0,0 +1,37 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var templateBackend string
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Print a starter config file",
+	Long: `Print a commented config file listing every option for the given backend.
+
+Example:
+  srtran template --backend openai > config.toml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tmpl, err := config.Template(templateBackend)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(tmpl)
+		return nil
+	},
+}
+
+func init() {
+	templateCmd.Flags().StringVar(&templateBackend, "backend", "googleai", "backend to generate the config for: googleai, vertexai, openai, openrouter or lmstudio")
+
+	rootCmd.AddCommand(templateCmd)
+}
@@ -4,14 +4,34 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
 
-	"github.com/rs/zerolog"
+	"github.com/s0up4200/SRTran/internal/cache"
 	"github.com/s0up4200/SRTran/internal/config"
+	"github.com/s0up4200/SRTran/internal/glossary"
+	"github.com/s0up4200/SRTran/internal/media"
+	"github.com/s0up4200/SRTran/internal/plugin"
+	"github.com/s0up4200/SRTran/internal/progress"
+	"github.com/s0up4200/SRTran/internal/report"
 	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/telemetry"
 	"github.com/s0up4200/SRTran/internal/translate"
+	"github.com/s0up4200/SRTran/internal/webhook"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 var translateCmd = &cobra.Command{
@@ -22,102 +42,1125 @@ var translateCmd = &cobra.Command{
 Example:
   srtran translate -i input.srt -o output.srt -s english -t norwegian`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Validate flags
-		if inputFile == "" {
-			return fmt.Errorf("input file is required")
+		start := time.Now()
+
+		var result translateResult
+		err := runTranslate(cmd, &result)
+
+		// files that were started send their own webhook; this one
+		// reports runs that failed before any file was translated
+		if webhookURL != "" && result.FilesStarted == 0 {
+			payload := webhook.NewPayload(inputFile+inputDir, sourceLanguage, targetLanguage, result.Subtitles, time.Since(start), err)
+			if webhookErr := webhook.Send(cmd.Context(), webhookURL, webhookSecret, payload); webhookErr != nil {
+				log := newLogger()
+				log.Warn().Err(webhookErr).Str("url", webhookURL).Msg("failed to send webhook")
+			}
+		}
+
+		if err == nil && result.TelemetryURL != "" {
+			payload := telemetry.NewPayload(result.Backend, result.Model, result.Subtitles, time.Since(start), Version)
+			if telemetryErr := telemetry.Send(context.Background(), result.TelemetryURL, payload); telemetryErr != nil {
+				log := newLogger()
+				log.Debug().Err(telemetryErr).Msg("failed to send telemetry")
+			}
+		}
+
+		return err
+	},
+}
+
+// silenceNoise and silenceMinDuration are the silencedetect settings
+// used by --split-on-silence
+const (
+	silenceNoise       = "-30dB"
+	silenceMinDuration = 500 * time.Millisecond
+)
+
+// glossaryMinCount is the number of subtitle pairs two words must share
+// to be added to a --glossary-from-previous glossary
+const glossaryMinCount = 2
+
+// translateResult collects information about a translation run
+type translateResult struct {
+	Subtitles int
+	// FilesStarted counts the files whose translation was started
+	FilesStarted int
+	Backend      string
+	Model        string
+	// TelemetryURL is set when telemetry is enabled
+	TelemetryURL string
+}
+
+// runTranslate performs the translation, recording details in result
+func runTranslate(cmd *cobra.Command, result *translateResult) error {
+	// Validate flags
+	switch {
+	case inputFile == "" && inputDir == "":
+		return fmt.Errorf("input file or directory is required")
+	case inputFile != "" && inputDir != "":
+		return fmt.Errorf("--input and --input-dir cannot be used together")
+	case outputFile != "" && outputDir != "":
+		return fmt.Errorf("--output and --output-dir cannot be used together")
+	case outputTemplate != "" && (outputFile != "" || outputDir != ""):
+		return fmt.Errorf("--output-template cannot be used with --output or --output-dir")
+	case outputTemplate != "" && inputFile == stdioPath:
+		return fmt.Errorf("--output-template cannot be used when reading from stdin")
+	case printPrompt && (inputDir != "" || isGlob(inputFile)):
+		return fmt.Errorf("--print-prompt requires a single --input file")
+	case inputFile != "" && outputFile == "" && outputDir == "" && outputTemplate == "" && !printPrompt:
+		return fmt.Errorf("output file or directory is required")
+	case inputDir != "" && outputFile != "":
+		return fmt.Errorf("--output cannot be used with --input-dir")
+	case outputFile != "" && isGlob(inputFile):
+		return fmt.Errorf("--output-dir is required when --input is a glob pattern")
+	}
+	if targetLanguage == "" {
+		return fmt.Errorf("target language is required")
+	}
+	if sourceLanguage == "" {
+		return fmt.Errorf("source language is required")
+	}
+	var outputTmpl *template.Template
+	if outputTemplate != "" {
+		var err error
+		if outputTmpl, err = parseOutputTemplate(outputTemplate); err != nil {
+			return err
+		}
+	}
+	if splitOnSilence && audioFile == "" {
+		return fmt.Errorf("--audio is required with --split-on-silence")
+	}
+	if reportFile != "" && (inputDir != "" || isGlob(inputFile)) {
+		return fmt.Errorf("--report can only be used with a single input file")
+	}
+	if metadataFile != "" && (inputDir != "" || isGlob(inputFile)) {
+		return fmt.Errorf("--metadata-file can only be used with a single input file")
+	}
+	if progressMarkdown != "" && (inputDir != "" || isGlob(inputFile)) {
+		return fmt.Errorf("--progress-markdown can only be used with a single input file")
+	}
+	if preTranslateScript != "" {
+		if inputFile == stdioPath {
+			return fmt.Errorf("--pre-translate-script cannot be used when reading from stdin")
+		}
+		script, err := resolveScript(preTranslateScript)
+		if err != nil {
+			return err
+		}
+		preTranslateScript = script
+	}
+	if postTranslateScript != "" {
+		if outputFile == stdioPath {
+			return fmt.Errorf("--post-translate-script cannot be used when writing to stdout")
+		}
+		script, err := resolveScript(postTranslateScript)
+		if err != nil {
+			return err
 		}
-		if outputFile == "" {
-			return fmt.Errorf("output file is required")
+		postTranslateScript = script
+	}
+	if (hashCheck || hashFile) && outputFile == stdioPath {
+		return fmt.Errorf("--hash-check and --hash-file cannot be used when writing to stdout")
+	}
+	if cmd.Flags().Changed("batch-index") && !printPrompt {
+		return fmt.Errorf("--batch-index requires --print-prompt")
+	}
+	if batchIndex < 0 {
+		return fmt.Errorf("--batch-index must not be negative")
+	}
+	if twoPassModel != "" && !twoPass {
+		return fmt.Errorf("--two-pass-model requires --two-pass")
+	}
+	if timedOutText != "" && requestTimeout <= 0 {
+		return fmt.Errorf("--timed-out-subtitle-text requires --request-timeout")
+	}
+	if splitOnSilence && inputDir != "" {
+		return fmt.Errorf("--split-on-silence cannot be used with --input-dir")
+	}
+
+	// keep stdout for the subtitles or prompt when writing to it
+	if outputFile == stdioPath || printPrompt {
+		logOutput = os.Stderr
+	}
+
+	if verbose {
+		fmt.Fprintf(logOutput, "Translating %s from %s to %s\n", inputFile+inputDir, sourceLanguage, targetLanguage)
+	}
+
+	// Get configuration
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if autoModel {
+		if cfg.Backend != "lmstudio" {
+			return fmt.Errorf("--auto-model is only supported by the lmstudio backend")
 		}
-		if targetLanguage == "" {
-			return fmt.Errorf("target language is required")
+		if cfg.Model == "" {
+			models, err := translate.LMStudioModels(cmd.Context(), cfg.BaseURL, cfg.APIKey)
+			if err != nil {
+				return err
+			}
+			if len(models) == 0 {
+				return fmt.Errorf("LM Studio has no models loaded")
+			}
+			cfg.Model = models[0]
 		}
-		if sourceLanguage == "" {
-			return fmt.Errorf("source language is required")
+	}
+
+	result.Backend = cfg.Backend
+	result.Model = cfg.Model
+	if telemetryEnabled || cfg.Telemetry {
+		if cfg.TelemetryURL == "" {
+			return fmt.Errorf("telemetry is enabled but telemetry_url is not set in the config file")
 		}
+		result.TelemetryURL = cfg.TelemetryURL
+	}
+
+	// Print configuration info
+	log := newLogger()
+	log.Info().
+		Str("backend", cfg.Backend).
+		Str("model", cfg.Model).
+		Msg("configuration loaded")
 
-		if verbose {
-			fmt.Printf("Translating %s from %s to %s\n", inputFile, sourceLanguage, targetLanguage)
+	if verbose {
+		if configFile != "" {
+			log.Debug().
+				Str("config_file", configFile).
+				Msg("using configuration file")
+		} else {
+			log.Debug().Msg("using environment variables")
 		}
+	}
+
+	// Output encoding flag overrides the config file
+	encodingName := cfg.OutputEncoding
+	if outputEncoding != "" {
+		encodingName = outputEncoding
+	}
+	encoding, err := srt.ParseEncoding(encodingName)
+	if err != nil {
+		return err
+	}
+
+	// Line ending flag overrides the config file
+	lineEndingName := cfg.LineEnding
+	if lineEnding != "" {
+		lineEndingName = lineEnding
+	}
+	ending, err := srt.ParseLineEnding(lineEndingName)
+	if err != nil {
+		return err
+	}
 
-		// Get configuration
-		cfg, err := config.LoadConfig(configFile)
+	mode := srt.WriteModeTranslatedOnly
+	if !writeTranslatedOnly {
+		if mode, err = srt.ParseWriteMode(writeMode); err != nil {
+			return err
+		}
+	}
+
+	// Initialize the SRT parser and writer
+	parser := srt.NewParser(verbose)
+	parser.Lenient = lenient
+
+	writer := srt.NewWriter(verbose)
+	writer.Mode = mode
+	writer.Separator = bilingualSeparator
+	writer.AnnotateSource = annotateSource
+	writer.Encoding = encoding
+	writer.LineEnding = ending
+	writer.OutputOriginalOnEmpty = outputOriginalOnEmpty
+
+	// Configure translation service
+	config, err := newServiceConfig(cfg)
+	if err != nil {
+		return err
+	}
+	// --keep-effects-translated=false is the same as --keep-effects-original
+	if keepEffectsOriginal || !keepEffectsTranslated {
+		config.Effects = translate.EffectsOriginal
+	}
+
+	if splitOnSilence {
+		silences, err := media.DetectSilence(cmd.Context(), audioFile, silenceNoise, silenceMinDuration)
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return fmt.Errorf("failed to detect silence: %w", err)
 		}
+		log.Info().Int("intervals", len(silences)).Msg("detected silence in audio")
+		config.Silences = silences
+	}
 
-		// Print configuration info
-		log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
-		log.Info().
-			Str("backend", cfg.Backend).
-			Str("model", cfg.Model).
-			Msg("configuration loaded")
-
-		if verbose {
-			if configFile != "" {
-				log.Debug().
-					Str("config_file", configFile).
-					Msg("using configuration file")
-			} else {
-				log.Debug().Msg("using environment variables")
+	if progressMarkdown != "" {
+		markdown := progress.NewMarkdown(progressMarkdown)
+		config.OnBatch = func(p translate.BatchProgress) {
+			if err := markdown.Update(p.Batch, p.Done, p.Total, p.Status); err != nil {
+				log.Warn().Err(err).Msg("failed to write progress report")
 			}
 		}
+	}
+
+	// Initialize translation service
+	service, err := translate.NewService(config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize translation service: %w", err)
+	}
+	defer service.Close()
 
-		// Initialize the SRT parser
-		parser := srt.NewParser(verbose)
+	var translator translate.Translator = service
+	if useCache {
+		path, err := cache.DefaultPath()
+		if err != nil {
+			return err
+		}
+		c, err := cache.Open(path, cacheTTL)
+		if err != nil {
+			return err
+		}
+		translator = translate.NewCachingTranslator(service, c, config.Backend, service.CurrentModel, log)
+	}
 
-		// Parse input file
-		subtitles, err := parser.Parse(inputFile)
+	t := &fileTranslator{
+		translator: translator,
+		config:     config,
+		parser:     parser,
+		writer:     writer,
+		stats:      service.Stats,
+		model:      service.CurrentModel,
+		modelCosts: cfg.ModelCosts,
+	}
+	if printPrompt {
+		subtitles, err := t.readInput(cmd.Context(), translateJob{Input: inputFile})
+		if err != nil {
+			return err
+		}
+		prompt, err := service.Prompt(subtitles, sourceLanguage, targetLanguage, batchIndex)
 		if err != nil {
-			return fmt.Errorf("failed to parse input file: %w", err)
+			return err
 		}
+		fmt.Println(prompt)
+		return nil
+	}
 
-		// Configure translation service
-		config := translate.ServiceConfig{
-			APIKey:  cfg.APIKey,
-			Model:   cfg.Model,
-			Verbose: verbose,
-			Backend: translate.Backend(cfg.Backend),
+	var jobs []translateJob
+	switch {
+	case inputDir != "":
+		jobs, err = globJobs(filepath.Join(inputDir, "*.srt"), targetLanguage, outputDir)
+	case outputDir != "" || (outputTmpl != nil && isGlob(inputFile)):
+		jobs, err = globJobs(inputFile, targetLanguage, outputDir)
+	default:
+		jobs = []translateJob{{Input: inputFile, Output: outputFile}}
+	}
+	if err != nil {
+		return err
+	}
+	if outputTmpl != nil {
+		if err := applyOutputTemplate(outputTmpl, jobs); err != nil {
+			return err
 		}
+	}
 
-		// Configure backend-specific settings
-		switch cfg.Backend {
-		case "openrouter":
-			config.BaseURL = "https://openrouter.ai/api/v1"
-		case "lmstudio":
-			config.BaseURL = cfg.BaseURL
+	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
 		}
+	}
+
+	if err := t.translateAll(cmd.Context(), jobs, parallelFiles, result); err != nil {
+		return err
+	}
 
-		// Initialize translation service
-		service, err := translate.NewService(config)
+	if translateTitle != "" {
+		lang := titleLang
+		if lang == "" {
+			lang = sourceLanguage
+		}
+		title, err := service.TranslateSingleText(cmd.Context(), translateTitle, lang, targetLanguage)
 		if err != nil {
-			return fmt.Errorf("failed to initialize translation service: %w", err)
+			return fmt.Errorf("failed to translate title: %w", err)
 		}
+		fmt.Fprintf(logOutput, "Translated title: %s\n", title)
+	}
+	return nil
+}
 
-		// Translate subtitles
-		translated, err := service.Translate(cmd.Context(), subtitles, sourceLanguage, targetLanguage)
-		if err != nil {
-			return fmt.Errorf("failed to translate subtitles: %w", err)
+// stdioPath as --input or --output reads from stdin or writes to stdout
+const stdioPath = "-"
+
+// translateJob is an input file and the path its translation is written to
+type translateJob struct {
+	Input  string
+	Output string
+}
+
+// globJobs returns a job for each file matching pattern, written to
+// <outputDir>/<name>.<target>.srt, or next to the input when outputDir
+// is empty. Files that already look like translations into target and
+// would be written next to themselves are skipped.
+func globJobs(pattern, target, outputDir string) ([]translateJob, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input pattern: %w", err)
+	}
+
+	suffix := "." + target + ".srt"
+	var jobs []translateJob
+	for _, input := range matches {
+		dir := outputDir
+		if dir == "" {
+			dir = filepath.Dir(input)
+		}
+		if strings.HasSuffix(input, suffix) && sameFile(filepath.Dir(input), dir) {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+		jobs = append(jobs, translateJob{
+			Input:  input,
+			Output: filepath.Join(dir, name+suffix),
+		})
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no subtitle files match %s", pattern)
+	}
+	return jobs, nil
+}
+
+// outputPathData holds the variables available to --output-template
+type outputPathData struct {
+	// Dir is the directory of the input file
+	Dir string
+	// Name is the input file name without its extension
+	Name string
+	// Ext is the extension of the input file, including the dot
+	Ext string
+	// Basename is the input file name with its extension
+	Basename   string
+	SourceLang string
+	TargetLang string
+}
+
+// parseOutputTemplate parses an --output-template and executes it once
+// against sample values so unknown fields are reported before translating
+func parseOutputTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, outputPathData{}); err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// applyOutputTemplate sets the output path of each job from tmpl and
+// creates the directories the outputs are written to
+func applyOutputTemplate(tmpl *template.Template, jobs []translateJob) error {
+	for i, job := range jobs {
+		base := filepath.Base(job.Input)
+		ext := filepath.Ext(base)
+		data := outputPathData{
+			Dir:        filepath.Dir(job.Input),
+			Name:       strings.TrimSuffix(base, ext),
+			Ext:        ext,
+			Basename:   base,
+			SourceLang: sourceLanguage,
+			TargetLang: targetLanguage,
+		}
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to execute output template for %s: %w", job.Input, err)
 		}
+		output := filepath.Clean(buf.String())
+		if sameFile(output, job.Input) {
+			return fmt.Errorf("output template writes %s over its input", job.Input)
+		}
+		if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		jobs[i].Output = output
+	}
+	return nil
+}
+
+// isGlob reports whether pattern contains glob metacharacters
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// fileTranslator translates subtitle files with a shared translator
+type fileTranslator struct {
+	translator translate.Translator
+	config     translate.ServiceConfig
+	parser     *srt.Parser
+	writer     *srt.Writer
+	// stats, model and modelCosts are used for --metadata-file; model
+	// returns the model in use, which changes when a fallback takes over
+	stats      func() translate.Stats
+	model      func() string
+	modelCosts map[string]config.ModelCost
+}
+
+// translateAll translates jobs, running up to parallel of them at once.
+// It stops starting new files after the first error and returns it. With
+// more than one job a progress line is written after each batch, and
+// with --webhook a webhook is sent as each file finishes.
+func (t *fileTranslator) translateAll(ctx context.Context, jobs []translateJob, parallel int, result *translateResult) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(parallel)
+
+	log := newLogger()
+
+	var (
+		mu   sync.Mutex
+		done int
+	)
 
-		// Write output file
-		if err := parser.Write(outputFile, translated); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
+	var bar *progress.Files
+	if len(jobs) > 1 {
+		inputs := make([]string, len(jobs))
+		for i, job := range jobs {
+			inputs[i] = job.Input
 		}
+		bar = progress.NewFiles(logOutput, inputs)
+	}
 
-		if verbose {
-			fmt.Printf("Successfully translated %s to %s\n", inputFile, outputFile)
+	for i, job := range jobs {
+		if ctx.Err() != nil {
+			break
 		}
-		return nil
-	},
+		mu.Lock()
+		result.FilesStarted++
+		mu.Unlock()
+		g.Go(func() error {
+			fileCtx := ctx
+			if bar != nil {
+				fileCtx = translate.WithOnBatch(ctx, func(p translate.BatchProgress) {
+					bar.Update(i, p.Done, p.Total)
+				})
+			}
+			start := time.Now()
+			count, err := t.translateFile(fileCtx, job)
+			if bar != nil && err == nil {
+				bar.Finish(i)
+			}
+			if webhookURL != "" {
+				// the run is cancelled after the first error, which must
+				// not stop that file's webhook
+				payload := webhook.NewPayload(job.Input, sourceLanguage, targetLanguage, count, time.Since(start), err)
+				if webhookErr := webhook.Send(context.WithoutCancel(ctx), webhookURL, webhookSecret, payload); webhookErr != nil {
+					log.Warn().Err(webhookErr).Str("url", webhookURL).Msg("failed to send webhook")
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Subtitles += count
+			done++
+			if len(jobs) > 1 {
+				log.Info().
+					Str("file", job.Output).
+					Int("files_done", done).
+					Int("files_total", len(jobs)).
+					Int("percent", done*100/len(jobs)).
+					Msg("file translated")
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// readInput parses the input of job and applies the pre-processing flags
+func (t *fileTranslator) readInput(ctx context.Context, job translateJob) ([]srt.Subtitle, error) {
+	log := newLogger()
+
+	// Parse input file
+	var subtitles []srt.Subtitle
+	var err error
+	switch {
+	case preTranslateScript != "":
+		var out []byte
+		if out, err = runScript(ctx, preTranslateScript, job.Input); err == nil {
+			subtitles, err = t.parser.ParseReader(bytes.NewReader(out))
+		}
+	case job.Input == stdioPath:
+		subtitles, err = t.parser.ParseReader(os.Stdin)
+	default:
+		subtitles, err = t.parser.Parse(job.Input)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input file %s: %w", job.Input, err)
+	}
+
+	// Pre-processing
+	if ignoreEmpty {
+		before := len(subtitles)
+		subtitles = srt.FilterEmptySubtitles(subtitles)
+		log.Info().Int("removed", before-len(subtitles)).Msg("removed empty subtitles")
+	}
+	if minDuration > 0 {
+		before := len(subtitles)
+		subtitles = srt.FilterShortSubtitles(subtitles, minDuration)
+		log.Info().
+			Int("removed", before-len(subtitles)).
+			Dur("min_duration", minDuration).
+			Msg("removed short subtitles")
+	}
+	if compressSpace {
+		subtitles = srt.CompressWhitespace(subtitles)
+	}
+	if splitMerged {
+		subtitles = srt.SplitMergedLines(subtitles)
+	}
+	if stripMusicNotes {
+		subtitles = srt.StripMusicNotes(subtitles)
+	}
+	return subtitles, nil
+}
+
+// translateFile translates a single file and returns the number of
+// subtitles translated
+func (t *fileTranslator) translateFile(ctx context.Context, job translateJob) (int, error) {
+	log := newLogger()
+
+	subtitles, err := t.readInput(ctx, job)
+	if err != nil {
+		return 0, err
+	}
+
+	// Translate subtitles
+	start := time.Now()
+	translated, err := t.translator.Translate(ctx, subtitles, sourceLanguage, targetLanguage)
+	var partial *translate.PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return 0, fmt.Errorf("failed to translate %s: %w", job.Input, err)
+	}
+	elapsed := time.Since(start)
+
+	// Post-processing
+	if stripMusicNotes {
+		translated = srt.RestoreMusicNotes(translated)
+	}
+	if splitMerged {
+		translated = srt.JoinMergedLines(translated)
+	}
+	if prependText != "" {
+		translated = srt.PrependCredit(translated, prependText)
+	}
+	if appendText != "" {
+		translated = srt.AppendCredit(translated, appendText)
+	}
+
+	for _, issue := range srt.FindUnencodable(translated, t.writer.Encoding) {
+		log.Warn().
+			Int("index", issue.Index).
+			Str("char", string(issue.Char)).
+			Str("encoding", string(t.writer.Encoding)).
+			Msg("character cannot be represented in output encoding and will be replaced")
+	}
+
+	// Back up the input before it is overwritten
+	if job.Input != stdioPath && sameFile(job.Input, job.Output) {
+		if backup {
+			backupPath, err := backupFile(job.Input, backupSuffix)
+			if err != nil {
+				return 0, fmt.Errorf("failed to back up input file: %w", err)
+			}
+			log.Info().Str("backup", backupPath).Msg("backed up input file")
+		} else {
+			log.Warn().Msg("output file is the same as the input file and will be overwritten; use --backup to keep a copy")
+		}
+	}
+
+	// Write output file
+	if job.Output == stdioPath {
+		err = t.writer.WriteWriter(os.Stdout, translated)
+	} else {
+		err = t.writer.Write(job.Output, translated)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	if postTranslateScript != "" {
+		if _, err := runScript(ctx, postTranslateScript, job.Output); err != nil {
+			return 0, err
+		}
+	}
+
+	if reportFile != "" {
+		if err := t.writeReport(job, subtitles, translated, elapsed); err != nil {
+			return 0, err
+		}
+	}
+
+	if metadataFile != "" {
+		if err := t.writeMetadata(job, subtitles); err != nil {
+			return 0, err
+		}
+	}
+
+	if hashCheck || hashFile {
+		sum, err := fileSHA256(job.Output)
+		if err != nil {
+			return 0, err
+		}
+		if hashCheck {
+			fmt.Printf("SHA256: %s  %s\n", sum, job.Output)
+		}
+		if hashFile {
+			line := fmt.Sprintf("%s  %s\n", sum, filepath.Base(job.Output))
+			if err := os.WriteFile(job.Output+".sha256", []byte(line), 0o644); err != nil {
+				return 0, fmt.Errorf("failed to write hash file: %w", err)
+			}
+		}
+	}
+
+	if partial != nil {
+		for _, f := range partial.Failed {
+			log.Warn().
+				Str("file", job.Output).
+				Int("first_index", f.FirstIndex).
+				Int("last_index", f.LastIndex).
+				Msg("batch left untranslated")
+		}
+		log.Warn().
+			Str("file", job.Output).
+			Int("failed_batches", len(partial.Failed)).
+			Msg("some batches failed; their original text was written")
+		return len(subtitles), nil
+	}
+
+	if verbose {
+		fmt.Fprintf(logOutput, "Successfully translated %s to %s\n", job.Input, job.Output)
+	}
+	return len(subtitles), nil
 }
 
 func init() {
-	translateCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
-	translateCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	translateCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file, or - for stdin")
+	translateCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file, or - for stdout")
+	translateCmd.Flags().StringVar(&outputDir, "output-dir", "", "write translations to <dir>/<name>.<target-language>.srt; --input may then be a glob pattern")
+	translateCmd.Flags().StringVar(&outputTemplate, "output-template", "", "Go template for output paths, e.g. '{{.Dir}}/{{.Name}}.{{.TargetLang}}.srt'")
+	translateCmd.Flags().StringVar(&inputDir, "input-dir", "", "translate every .srt file in this directory to <name>.<target-language>.srt")
+	translateCmd.Flags().BoolVar(&tempByLength, "temperature-by-subtitle", false, "hint a low temperature for short subtitles and a higher one for long subtitles in the prompt")
+	translateCmd.Flags().StringVar(&translateTitle, "translate-title", "", "also translate this title to the target language and print it")
+	translateCmd.Flags().StringVar(&titleLang, "title-lang", "", "language of --translate-title (default the source language)")
+	translateCmd.Flags().BoolVar(&printPrompt, "print-prompt", false, "print the prompt for the first batch and exit without translating")
+	translateCmd.Flags().IntVar(&batchIndex, "batch-index", 0, "with --print-prompt, print the prompt for this batch, counting from 0")
+	translateCmd.Flags().BoolVar(&shuffleValidate, "shuffle-validate", false, "send each batch in random order and check the response markers restore it (debugging, use on a short file)")
+	translateCmd.Flags().IntVar(&parallelFiles, "parallel-files", 1, "number of input files to translate at once")
 	translateCmd.Flags().StringVarP(&sourceLanguage, "source-language", "s", "", "source language (e.g., 'english', 'spanish')")
 	translateCmd.Flags().StringVarP(&targetLanguage, "target-language", "t", "", "target language (e.g., 'norwegian', 'german')")
 	translateCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	translateCmd.Flags().StringVar(&chaptersFile, "chapters", "", "MKVMerge chapter file; batches never span a chapter boundary")
+	translateCmd.Flags().StringSliceVar(&modelFallback, "model-fallback", nil, "comma-separated models to try in order on quota or auth errors")
+	translateCmd.Flags().IntVar(&batchSize, "batch-size", 0, "number of subtitles sent per request (default 20)")
+	translateCmd.Flags().BoolVar(&failFast, "fail-fast", false, "abort on the first translation error instead of retrying")
+	translateCmd.Flags().IntVar(&nCandidates, "n-candidates", 1, "number of translations to request per batch (openai only); pick between them interactively on a terminal")
+	translateCmd.Flags().BoolVar(&keepEffectsTranslated, "keep-effects-translated", true, "translate sound-effect annotations such as [MUSIC PLAYS] into the target language")
+	translateCmd.Flags().BoolVar(&keepEffectsOriginal, "keep-effects-original", false, "keep sound-effect annotations in the source language")
+	translateCmd.MarkFlagsMutuallyExclusive("keep-effects-translated", "keep-effects-original")
+	translateCmd.Flags().StringVar(&rateLimitStrategy, "rate-limit-strategy", "", "rate limiting for rpm: fixed, or adaptive to the API's remaining-requests headers (default fixed)")
+	translateCmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "keep the original text of batches that fail and translate the rest instead of aborting")
+	translateCmd.Flags().DurationVar(&requestTimeout, "request-timeout", 0, "give up on a request to the backend after this long and retry it (0 disables)")
+	translateCmd.Flags().StringVar(&timedOutText, "timed-out-subtitle-text", "", "with --request-timeout, use this text for subtitles of batches that keep timing out instead of aborting")
+	translateCmd.Flags().BoolVar(&noRetry, "no-retry", false, "make exactly one attempt per batch, without retries or model fallback")
+	translateCmd.Flags().BoolVar(&useCache, "cache", false, "reuse cached translations and cache new ones")
+	translateCmd.Flags().DurationVar(&cacheTTL, "cache-ttl", cache.DefaultTTL, "how long cached translations are used")
+	translateCmd.Flags().IntVar(&maxTokens, "max-tokens-per-batch", 0, "end a batch early once its estimated token count exceeds this (0 disables)")
+	translateCmd.Flags().StringVar(&tokenizerModel, "tokenizer-model", translate.DefaultTokenizerModel, "tiktoken encoding used to estimate tokens: cl100k_base, o200k_base or p50k_base")
+	translateCmd.Flags().BoolVar(&toneDetection, "tone-detection", false, "detect the tone of the source and add a matching rule to the prompt")
+	translateCmd.Flags().BoolVar(&glossaryFromPrevious, "glossary-from-previous", false, "learn a glossary from --previous-original and --previous-translated and add it to the prompt")
+	translateCmd.Flags().StringVar(&previousOriginal, "previous-original", "", "original subtitle file of an earlier translation, e.g. the previous episode")
+	translateCmd.Flags().StringVar(&previousTranslated, "previous-translated", "", "translation of --previous-original")
+	translateCmd.Flags().StringVar(&promptTemplate, "system-prompt-template", "", "Go text/template file replacing the built-in translation prompt")
+	translateCmd.Flags().StringVar(&promptPlugin, "plugin", "", "installed prompt plugin that rewrites each prompt before it is sent")
+	translateCmd.Flags().IntVar(&sourceContextLines, "source-context-lines", 0, "add this many lines of original text from before and after each batch to its prompt, as context that is not translated")
+	translateCmd.Flags().BoolVar(&openAIJSONSchema, "openai-json-schema", false, "request OpenAI structured outputs (JSON schema) instead of parsing separators; needs gpt-4o or later")
+	translateCmd.Flags().StringVar(&openAIOrganization, "openai-organization", "", "OpenAI organization ID sent as the OpenAI-Organization header")
+	translateCmd.Flags().BoolVar(&geminiGrounding, "gemini-grounding", false, "let Gemini use Google Search to translate names and places consistently (billed per request)")
+	translateCmd.Flags().StringVar(&googleAISafetyThreshold, "google-ai-safety-threshold", translate.DefaultSafetyThreshold, "Google AI safety filter level: block_none, block_only_high, block_medium_and_above or block_low_and_above")
+	translateCmd.Flags().StringVar(&googleAIRegion, "google-ai-region", "", "Google Cloud region for the vertexai backend (default us-central1)")
+	translateCmd.Flags().StringVar(&openRouterSiteURL, "openrouter-site-url", "", "site URL sent as HTTP-Referer to OpenRouter")
+	translateCmd.Flags().StringVar(&openRouterAppName, "openrouter-app-name", "", "app name sent as X-Title to OpenRouter")
+	translateCmd.Flags().StringSliceVar(&openRouterTransforms, "openrouter-transforms", nil, "OpenRouter prompt transforms, e.g. middle-out, or none to disable them")
+	translateCmd.Flags().IntVar(&lmStudioTTL, "lmstudio-ttl", translate.DefaultLMStudioKeepAlive, "seconds LM Studio keeps the model loaded after each request (0 leaves it to LM Studio)")
+	translateCmd.Flags().BoolVar(&autoModel, "auto-model", false, "with the lmstudio backend and no model configured, use the first model LM Studio lists")
+	translateCmd.Flags().StringSliceVar(&openRouterProviders, "openrouter-provider", nil, "providers for OpenRouter to prefer, in order (comma-separated or repeated)")
+	translateCmd.Flags().BoolVar(&splitOnSilence, "split-on-silence", false, "end batches at silences in the audio (requires --audio and ffmpeg)")
+	translateCmd.Flags().StringVar(&audioFile, "audio", "", "audio or video file used by --split-on-silence")
+	translateCmd.Flags().BoolVar(&lenient, "lenient", false, "recover from malformed subtitle blocks instead of misreading them")
+	translateCmd.Flags().DurationVar(&minDuration, "min-duration", 0, "remove subtitles shown for less than this duration before translating (e.g., 300ms)")
+	translateCmd.Flags().BoolVar(&ignoreEmpty, "ignore-empty", false, "remove subtitles with empty or whitespace-only text before translating")
+	translateCmd.Flags().IntVar(&maxTextLines, "max-text-per-subtitle", 0, "truncate subtitles with more text lines than this before translating, discarding the rest (0 disables)")
+	translateCmd.Flags().BoolVar(&compressSpace, "compress-whitespace", false, "collapse repeated spaces and tabs and trim each line before translating")
+	translateCmd.Flags().BoolVar(&splitMerged, "split-merged-lines", false, "split lines joined with | into separate lines before translating and join them again afterwards")
+	translateCmd.Flags().BoolVar(&stripMusicNotes, "strip-music-notes", false, "remove ♪ ♫ ♬ ♩ before translating and put them back afterwards")
+	translateCmd.Flags().BoolVar(&twoPass, "two-pass", false, "send the translations through a second pass that rephrases them to sound natural")
+	translateCmd.Flags().StringVar(&twoPassModel, "two-pass-model", "", "model for the --two-pass requests (default the translation model)")
+	translateCmd.Flags().Float64Var(&retranslateThreshold, "retranslate-threshold", 0, "retranslate subtitles whose translation is more similar to the original than this ratio (0-1, 0 disables)")
+	translateCmd.Flags().IntVar(&maxCharsPerLine, "max-chars-per-line", 0, "length limit of translated lines, handled according to --line-limit-mode (0 disables the limit)")
+	translateCmd.Flags().StringVar(&lineLimitMode, "line-limit-mode", translate.LineLimitWarn, "long translated lines: warn, wrap or truncate")
+	translateCmd.Flags().StringVar(&wordWrapAlgorithm, "word-wrap-algorithm", string(srt.WrapGreedy), "line wrapping algorithm: greedy or smart")
+	translateCmd.Flags().StringVar(&outputEncoding, "output-encoding", "", "output character encoding: utf8 (default), utf8bom, latin1 or cp1252")
+	translateCmd.Flags().StringVar(&outputEncoding, "char-encoding-output", "", "alias for --output-encoding")
+	translateCmd.Flags().StringVar(&lineEnding, "line-ending", "", "output line endings: lf (default), crlf or platform (crlf on Windows)")
+	translateCmd.Flags().BoolVar(&outputOriginalOnEmpty, "output-original-on-empty", true, "write the original text of subtitles left untranslated; false writes them as empty blocks")
+	translateCmd.Flags().StringVar(&prependText, "prepend-text", "", "add an untranslated block with this text for the first 3 seconds, e.g. a credit")
+	translateCmd.Flags().StringVar(&appendText, "append-text", "", "add an untranslated block with this text for 3 seconds after the last subtitle")
+	translateCmd.Flags().StringVar(&writeMode, "write-mode", string(srt.WriteModeTranslatedOnly), "text to write: translated, original or bilingual (translation above the original)")
+	translateCmd.Flags().BoolVar(&writeTranslatedOnly, "write-translated-only", false, "write only the translation, falling back to the original (same as --write-mode translated)")
+	translateCmd.Flags().StringVar(&bilingualSeparator, "bilingual-separator", "", "line written between the translation and the original in bilingual mode")
+	translateCmd.Flags().BoolVar(&annotateSource, "annotate-source", false, "write original lines below each translation as '# Original:' comments")
+	translateCmd.Flags().StringVar(&preTranslateScript, "pre-translate-script", "", "executable run with the input path as $1 before translating; its stdout is used as the input SRT")
+	translateCmd.Flags().StringVar(&postTranslateScript, "post-translate-script", "", "executable run with the output path as $1 after writing")
+	translateCmd.Flags().StringVar(&metadataFile, "metadata-file", "", "write JSON metadata about the translation (languages, model, tokens, cost, retries) to this file")
+	translateCmd.Flags().StringVar(&progressMarkdown, "progress-markdown", "", "rewrite a Markdown progress table in this file after each batch, e.g. $GITHUB_STEP_SUMMARY")
+	translateCmd.Flags().StringVar(&reportFile, "report", "", "write an HTML summary of the translation to this file")
+	translateCmd.Flags().BoolVar(&hashCheck, "hash-check", false, "print the SHA-256 of the output file after writing it")
+	translateCmd.Flags().BoolVar(&hashFile, "hash-file", false, "write the SHA-256 of the output file to <output>.sha256")
+	translateCmd.Flags().BoolVar(&backup, "backup", false, "back up the input file when it is also the output file")
+	translateCmd.Flags().BoolVar(&backupSuffix, "backup-suffix", false, "include a timestamp in the backup file name")
+	translateCmd.Flags().StringVar(&webhookURL, "webhook", "", "URL to POST a JSON summary to when translation completes")
+	translateCmd.Flags().StringVar(&webhookSecret, "webhook-secret", "", "secret used to sign webhook requests (X-SRTran-Signature)")
+	translateCmd.Flags().BoolVar(&telemetryEnabled, "telemetry", false, "send anonymous usage statistics to telemetry_url after a successful translation")
+	registerLanguageCompletion(translateCmd)
+	if err := translateCmd.RegisterFlagCompletionFunc("title-lang", completeLanguages); err != nil {
+		panic(err)
+	}
 
 	rootCmd.AddCommand(translateCmd)
 }
+
+// newServiceConfig builds the translation service configuration from the
+// loaded config and command-line flags
+func newServiceConfig(cfg *config.Config) (translate.ServiceConfig, error) {
+	config := translate.ServiceConfig{
+		APIKey:               cfg.APIKey,
+		Model:                cfg.Model,
+		ModelFallback:        cfg.ModelFallback,
+		Verbose:              verbose,
+		Backend:              translate.Backend(cfg.Backend),
+		RPM:                  cfg.RPM,
+		BurstSize:            cfg.BurstSize,
+		RateLimitStrategy:    cfg.RateLimitStrategy,
+		BatchSize:            cfg.BatchSize,
+		FailFast:             failFast,
+		ContinueOnError:      continueOnError,
+		RequestTimeout:       requestTimeout,
+		TimedOutText:         timedOutText,
+		UseJSONSchema:        openAIJSONSchema,
+		SafetyThreshold:      googleAISafetyThreshold,
+		Grounding:            geminiGrounding,
+		NoRetry:              noRetry,
+		Effects:              translate.EffectsTranslated,
+		ToneDetection:        toneDetection,
+		ToneRules:            cfg.ToneRules,
+		MaxTokensPerBatch:    maxTokens,
+		TokenizerModel:       tokenizerModel,
+		RetranslateThreshold: retranslateThreshold,
+		SourceContextLines:   sourceContextLines,
+		TempByLength:         tempByLength,
+		TwoPass:              twoPass,
+		TwoPassModel:         twoPassModel,
+		ShuffleValidate:      shuffleValidate,
+	}
+	logger := newLogger()
+	config.Logger = &logger
+	if nCandidates > 1 {
+		if translate.Backend(cfg.Backend) != translate.BackendOpenAI {
+			return config, fmt.Errorf("--n-candidates is only supported by the openai backend")
+		}
+		config.NCandidates = nCandidates
+		// prompt only when stdin is a terminal and not the input
+		if isTerminal(os.Stdin) && inputFile != stdioPath {
+			config.ChooseCandidate = chooseCandidate
+		}
+	}
+	if len(modelFallback) > 0 {
+		config.ModelFallback = modelFallback
+	}
+	if rateLimitStrategy != "" {
+		config.RateLimitStrategy = rateLimitStrategy
+	}
+	if batchSize > 0 {
+		config.BatchSize = batchSize
+	}
+	if retranslateThreshold < 0 || retranslateThreshold > 1 {
+		return config, fmt.Errorf("retranslate threshold must be between 0 and 1")
+	}
+
+	wrapAlgorithm, err := srt.ParseWrapAlgorithm(wordWrapAlgorithm)
+	if err != nil {
+		return config, err
+	}
+	config.MaxCharsPerLine = maxCharsPerLine
+	config.LineLimitMode = lineLimitMode
+	config.MaxTextLinesPerSubtitle = maxTextLines
+	config.WrapAlgorithm = wrapAlgorithm
+
+	if promptTemplate != "" {
+		tmpl, err := os.ReadFile(promptTemplate)
+		if err != nil {
+			return config, fmt.Errorf("failed to read prompt template: %w", err)
+		}
+		config.PromptTemplate = string(tmpl)
+	}
+
+	if promptPlugin != "" {
+		if err := plugin.Check(promptPlugin); err != nil {
+			return config, err
+		}
+		config.RewritePrompt = func(sourceLang, targetLang, prompt string) (string, error) {
+			resp, err := plugin.Run(context.Background(), promptPlugin, plugin.Request{
+				SourceLang: sourceLang,
+				TargetLang: targetLang,
+				Prompt:     prompt,
+			})
+			return resp.Prompt, err
+		}
+	}
+
+	if glossaryFromPrevious {
+		glossaryText, err := previousGlossary(previousOriginal, previousTranslated)
+		if err != nil {
+			return config, err
+		}
+		config.Glossary = glossaryText
+	}
+
+	if chaptersFile != "" {
+		chapters, err := srt.ParseChapters(chaptersFile)
+		if err != nil {
+			return config, fmt.Errorf("failed to parse chapters file: %w", err)
+		}
+		config.Chapters = chapters
+	}
+
+	// Configure backend-specific settings
+	switch cfg.Backend {
+	case "openai":
+		config.Organization = cfg.OpenAIOrganization
+		if openAIOrganization != "" {
+			config.Organization = openAIOrganization
+		}
+	case "openrouter":
+		config.BaseURL = translate.DefaultOpenRouterBaseURL
+		config.OpenRouterSiteURL = cfg.OpenRouterSiteURL
+		if openRouterSiteURL != "" {
+			config.OpenRouterSiteURL = openRouterSiteURL
+		}
+		config.OpenRouterAppName = cfg.OpenRouterAppName
+		if openRouterAppName != "" {
+			config.OpenRouterAppName = openRouterAppName
+		}
+		config.OpenRouterProviderOrder = cfg.OpenRouterProviderOrder
+		if len(openRouterProviders) > 0 {
+			config.OpenRouterProviderOrder = openRouterProviders
+		}
+		config.OpenRouterTransforms = cfg.OpenRouterTransforms
+		if len(openRouterTransforms) > 0 {
+			config.OpenRouterTransforms = openRouterTransforms
+		}
+	case "lmstudio":
+		config.BaseURL = cfg.BaseURL
+		config.LMStudioKeepAlive = lmStudioTTL
+	case "vertexai":
+		config.ProjectID = cfg.ProjectID
+		config.Location = cfg.Location
+		if googleAIRegion != "" {
+			config.Location = googleAIRegion
+		}
+	}
+
+	return config, nil
+}
+
+// previousGlossary learns a glossary from an earlier translation of
+// related subtitles, such as a previous episode
+func previousGlossary(originalPath, translatedPath string) (string, error) {
+	if originalPath == "" || translatedPath == "" {
+		return "", fmt.Errorf("--previous-original and --previous-translated are required with --glossary-from-previous")
+	}
+
+	parser := srt.NewParser(verbose)
+	original, err := parser.Parse(originalPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse previous original: %w", err)
+	}
+	translated, err := parser.Parse(translatedPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse previous translation: %w", err)
+	}
+
+	entries := glossary.Align(original, translated, glossaryMinCount)
+	log := newLogger()
+	log.Info().Int("terms", min(len(entries), glossary.MaxEntries)).Msg("learned glossary from previous translation")
+	return glossary.Format(entries), nil
+}
+
+// sameFile reports whether two paths refer to the same file
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
+
+// writeMetadata writes the --metadata-file JSON description of a
+// translated file
+func (t *fileTranslator) writeMetadata(job translateJob, subtitles []srt.Subtitle) error {
+	model := t.model()
+	usage := translate.EstimateUsage(subtitles, sourceLanguage, targetLanguage, t.config.BatchSize, t.config.TokenizerModel)
+	stats := t.stats()
+
+	metadata := report.Metadata{
+		InputFile:           filepath.Base(job.Input),
+		OutputFile:          filepath.Base(job.Output),
+		SourceLanguage:      sourceLanguage,
+		TargetLanguage:      targetLanguage,
+		Backend:             string(t.config.Backend),
+		Model:               model,
+		TranslatedAt:        time.Now().UTC().Truncate(time.Second),
+		Subtitles:           len(subtitles),
+		InputTokens:         usage.InputTokens,
+		OutputTokens:        usage.OutputTokens,
+		TotalTokens:         usage.InputTokens + usage.OutputTokens,
+		AverageBatchSeconds: stats.AverageBatchTime().Seconds(),
+		Retries:             stats.Retries,
+		SRTranVersion:       Version,
+	}
+	if cost, ok := t.modelCosts[model]; ok {
+		total := float64(usage.InputTokens)/1e6*cost.Input + float64(usage.OutputTokens)/1e6*cost.Output
+		metadata.TotalCost = &total
+	}
+	if err := report.WriteMetadata(metadataFile, metadata); err != nil {
+		return err
+	}
+
+	log := newLogger()
+	log.Info().Str("metadata", metadataFile).Msg("wrote translation metadata")
+	return nil
+}
+
+// writeReport writes the --report HTML summary of a translated file
+func (t *fileTranslator) writeReport(job translateJob, subtitles, translated []srt.Subtitle, elapsed time.Duration) error {
+	var srtData bytes.Buffer
+	if err := t.writer.WriteWriter(&srtData, translated); err != nil {
+		return err
+	}
+
+	model := t.model()
+	usage := translate.EstimateUsage(subtitles, sourceLanguage, targetLanguage, t.config.BatchSize, t.config.TokenizerModel)
+
+	summary := report.Summary{
+		Backend:      string(t.config.Backend),
+		Model:        model,
+		Source:       sourceLanguage,
+		Target:       targetLanguage,
+		Duration:     elapsed.Round(time.Second),
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+	}
+	if err := report.WriteHTML(reportFile, summary, translated, filepath.Base(job.Output), srtData.Bytes()); err != nil {
+		return err
+	}
+
+	log := newLogger()
+	log.Info().Str("report", reportFile).Msg("wrote translation report")
+	return nil
+}
+
+// runScript runs the executable script with path as its only argument and
+// returns its stdout. The script's stderr is passed through.
+func runScript(ctx context.Context, script, path string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, script, path)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("script %s failed: %w", script, err)
+	}
+	return out, nil
+}
+
+// resolveScript returns the absolute path of the script at path, which
+// is relative to the working directory, or an error unless it is an
+// executable file. exec looks bare names up in PATH, so the script is
+// run by its absolute path.
+func resolveScript(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve script: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat script: %w", err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return "", fmt.Errorf("script %s is not executable", path)
+	}
+	return abs, nil
+}
+
+// fileSHA256 returns the hex SHA-256 of the file at path
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open output file: %w", err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", fmt.Errorf("failed to hash output file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// backupFile copies path to <path>.bak, or <path>.<timestamp>.bak when timestamped is set.
+// The copy is written to a temporary file first and renamed into place.
+func backupFile(path string, timestamped bool) (string, error) {
+	backupPath := path + ".bak"
+	if timestamped {
+		backupPath = fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102-150405"))
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to copy file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), backupPath); err != nil {
+		return "", fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return backupPath, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	truncateAt string
+	keepAfter  bool
+)
+
+var truncateCmd = &cobra.Command{
+	Use:   "truncate",
+	Short: "Cut a subtitle file at a timestamp",
+	Long: `Remove all subtitle blocks that start after the given timestamp.
+
+With --keep-after only the blocks starting after the timestamp are kept.
+Useful for splitting a merged multi-episode subtitle file.
+
+Example:
+  srtran truncate -i full.srt --at 01:30:00,000 -o first-half.srt
+  srtran truncate -i full.srt --at 01:30:00,000 --keep-after -o second-half.srt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if outputFile == "" {
+			return fmt.Errorf("output file is required")
+		}
+		if truncateAt == "" {
+			return fmt.Errorf("--at timestamp is required")
+		}
+
+		at, err := srt.ParseTimestamp(truncateAt)
+		if err != nil {
+			return fmt.Errorf("invalid --at timestamp: %w", err)
+		}
+
+		parser := srt.NewParser(verbose)
+
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		kept, err := srt.Truncate(subtitles, at, keepAfter)
+		if err != nil {
+			return fmt.Errorf("failed to truncate subtitles: %w", err)
+		}
+
+		if err := srt.NewWriter(verbose).Write(outputFile, kept); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		if verbose {
+			fmt.Printf("Kept %d of %d subtitles in %s\n", len(kept), len(subtitles), outputFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	truncateCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	truncateCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output subtitle file")
+	truncateCmd.Flags().StringVar(&truncateAt, "at", "", "timestamp to cut at (e.g., 01:30:00,000)")
+	truncateCmd.Flags().BoolVar(&keepAfter, "keep-after", false, "keep only subtitles starting after the timestamp")
+
+	rootCmd.AddCommand(truncateCmd)
+}
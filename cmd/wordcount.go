@@ -0,0 +1,163 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/s0up4200/SRTran/internal/lang"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	wordcountTop      int
+	wordcountMinCount int
+	wordcountFormat   string
+	wordcountLanguage string
+)
+
+// wordCount is a word and the number of times it occurs
+type wordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+var wordcountCmd = &cobra.Command{
+	Use:   "wordcount",
+	Short: "Count word frequencies in a subtitle file",
+	Long: `Count how often each word occurs in a subtitle file and print the most
+frequent ones. Stopwords of the subtitle language are left out; the
+language is detected from the text unless --language is given.
+
+Useful for reviewing vocabulary before building a glossary.
+
+Example:
+  srtran wordcount -i movie.srt --top 50 --min-count 5`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inputFile == "" {
+			return fmt.Errorf("input file is required")
+		}
+		if wordcountFormat != "table" && wordcountFormat != "json" {
+			return fmt.Errorf("unknown format: %s (expected table or json)", wordcountFormat)
+		}
+
+		parser := srt.NewParser(verbose)
+		subtitles, err := parser.Parse(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse input file: %w", err)
+		}
+
+		words := subtitleWords(subtitles)
+
+		language := wordcountLanguage
+		if language == "" {
+			language = lang.DetectFromWords(words)
+		}
+		if verbose && language != "" {
+			fmt.Printf("Excluding %s stopwords\n", language)
+		}
+
+		counts := countWords(words, lang.Stopwords[language], wordcountMinCount)
+		if wordcountTop > 0 && len(counts) > wordcountTop {
+			counts = counts[:wordcountTop]
+		}
+
+		if wordcountFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(counts)
+		}
+
+		for _, c := range counts {
+			fmt.Printf("%7d  %s\n", c.Count, c.Word)
+		}
+		return nil
+	},
+}
+
+// subtitleWords returns the lowercase words of all subtitle text, with
+// surrounding punctuation and formatting tags removed
+func subtitleWords(subtitles []srt.Subtitle) []string {
+	var words []string
+	for _, sub := range subtitles {
+		for _, line := range sub.Text {
+			for _, field := range strings.Fields(stripTags(line)) {
+				word := strings.TrimFunc(strings.ToLower(field), func(r rune) bool {
+					return unicode.IsPunct(r) || unicode.IsSymbol(r)
+				})
+				if word != "" && !isNumber(word) {
+					words = append(words, word)
+				}
+			}
+		}
+	}
+	return words
+}
+
+// stripTags removes <...> and {...} formatting tags from line
+func stripTags(line string) string {
+	var b strings.Builder
+	var closing rune
+	for _, r := range line {
+		switch {
+		case closing != 0:
+			if r == closing {
+				closing = 0
+			}
+		case r == '<':
+			closing = '>'
+		case r == '{':
+			closing = '}'
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isNumber reports whether word consists only of digits
+func isNumber(word string) bool {
+	return strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+}
+
+// countWords counts words that are not stopwords, keeps those occurring at
+// least minCount times and sorts them by count, then alphabetically
+func countWords(words []string, stopwords map[string]bool, minCount int) []wordCount {
+	freq := make(map[string]int)
+	for _, w := range words {
+		if !stopwords[w] {
+			freq[w]++
+		}
+	}
+
+	counts := make([]wordCount, 0, len(freq))
+	for w, n := range freq {
+		if n >= minCount {
+			counts = append(counts, wordCount{Word: w, Count: n})
+		}
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Word < counts[j].Word
+	})
+	return counts
+}
+
+func init() {
+	wordcountCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input subtitle file")
+	wordcountCmd.Flags().IntVar(&wordcountTop, "top", 50, "number of words to print (0 for all)")
+	wordcountCmd.Flags().IntVar(&wordcountMinCount, "min-count", 1, "leave out words occurring fewer times")
+	wordcountCmd.Flags().StringVar(&wordcountFormat, "format", "table", "output format: table or json")
+	wordcountCmd.Flags().StringVar(&wordcountLanguage, "language", "", "ISO 639-1 code of the stopwords to exclude (default: detected)")
+
+	rootCmd.AddCommand(wordcountCmd)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/translate"
+)
+
+// mockTranslation is the hardcoded reply of the mock chat completions API
+const mockTranslation = `[1]
+Hallo
+===SUBTITLE===
+[2]
+Wie geht es dir?
+===SUBTITLE===
+[3]
+Mir geht es gut.
+===SUBTITLE===
+[4]
+Danke.
+===SUBTITLE===
+[5]
+Tschüss!`
+
+func TestTranslateEndToEnd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      "chatcmpl-test",
+			"object":  "chat.completion",
+			"created": 0,
+			"model":   "gpt-4o-mini",
+			"choices": []map[string]interface{}{{
+				"index":         0,
+				"finish_reason": "stop",
+				"message": map[string]string{
+					"role":    "assistant",
+					"content": mockTranslation,
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	service, err := translate.NewService(translate.ServiceConfig{
+		APIKey:  "test",
+		BaseURL: server.URL,
+		Model:   "gpt-4o-mini",
+		Backend: translate.BackendOpenAI,
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer service.Close()
+
+	texts := []string{"Hello", "How are you?", "I'm fine.", "Thanks.", "Bye!"}
+	subtitles := make([]srt.Subtitle, len(texts))
+	for i, text := range texts {
+		subtitles[i] = srt.Subtitle{
+			Index: i + 1,
+			Start: fmt.Sprintf("00:00:%02d,000", i*2),
+			End:   fmt.Sprintf("00:00:%02d,500", i*2+1),
+			Text:  []string{text},
+		}
+	}
+
+	translated, err := service.Translate(context.Background(), subtitles, "english", "german")
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if len(translated) != len(subtitles) {
+		t.Fatalf("got %d subtitles, want %d", len(translated), len(subtitles))
+	}
+	for _, sub := range translated {
+		if strings.TrimSpace(strings.Join(sub.Translated, "")) == "" {
+			t.Errorf("subtitle %d has no translation", sub.Index)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := srt.NewWriter(false).WriteWriter(&out, translated); err != nil {
+		t.Fatalf("WriteWriter: %v", err)
+	}
+	parsed, err := srt.NewParser(false).ParseReader(&out)
+	if err != nil {
+		t.Fatalf("output is not valid SRT: %v", err)
+	}
+	if len(parsed) != len(subtitles) {
+		t.Errorf("output has %d subtitles, want %d", len(parsed), len(subtitles))
+	}
+	if got := strings.Join(parsed[4].Text, "\n"); got != "Tschüss!" {
+		t.Errorf("last subtitle = %q, want %q", got, "Tschüss!")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package anonymize replaces proper nouns in subtitles with placeholders
+// so they are never sent to a translation model, and restores them afterwards.
+package anonymize
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// Mapping maps placeholders such as PERSON_1 to the text they replaced
+type Mapping map[string]string
+
+var (
+	// properNounPattern matches two or more consecutive capitalized words
+	properNounPattern = regexp.MustCompile(`\p{Lu}\p{Ll}+(?: \p{Lu}\p{Ll}+)+`)
+	// placePrefixPattern matches prepositions that usually precede a place name
+	placePrefixPattern = regexp.MustCompile(`(?i)\b(?:in|at|from|to|near)\s+$`)
+	// sentenceStartPattern matches text ending where a new sentence begins
+	sentenceStartPattern = regexp.MustCompile(`(?:^|[.!?]\s+)$`)
+	// placeholderPattern matches placeholders written by Anonymize
+	placeholderPattern = regexp.MustCompile(`\b(?:PERSON|PLACE)_\d+\b`)
+)
+
+// Anonymize replaces capitalized multi-word sequences in subtitle text with
+// PERSON_N or PLACE_N placeholders. A sequence following a preposition such
+// as "in" or "from" is treated as a place. The same text always maps to the
+// same placeholder. A sentence-initial word is dropped from a sequence when
+// the rest of it also appears mid-sentence, so "Ask John Smith" yields
+// John Smith when John Smith is seen elsewhere.
+func Anonymize(subs []srt.Subtitle) ([]srt.Subtitle, Mapping) {
+	known := make(map[string]bool)
+	for _, sub := range subs {
+		for _, line := range sub.Text {
+			replaceProperNouns(line, func(name, before string) string {
+				if !sentenceStartPattern.MatchString(before) {
+					known[name] = true
+				}
+				return name
+			})
+		}
+	}
+
+	mapping := make(Mapping)
+	placeholders := make(map[string]string)
+	counts := make(map[string]int)
+
+	var assign func(name, before string) string
+	assign = func(name, before string) string {
+		if first, rest, ok := strings.Cut(name, " "); ok && !known[name] && known[rest] &&
+			sentenceStartPattern.MatchString(before) {
+			return first + " " + assign(rest, before+first+" ")
+		}
+		if placeholder, ok := placeholders[name]; ok {
+			return placeholder
+		}
+		kind := "PERSON"
+		if placePrefixPattern.MatchString(before) {
+			kind = "PLACE"
+		}
+		counts[kind]++
+		placeholder := fmt.Sprintf("%s_%d", kind, counts[kind])
+		placeholders[name] = placeholder
+		mapping[placeholder] = name
+		return placeholder
+	}
+
+	result := make([]srt.Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+		result[i].Text = make([]string, len(sub.Text))
+		for j, line := range sub.Text {
+			result[i].Text[j] = replaceProperNouns(line, assign)
+		}
+	}
+
+	return result, mapping
+}
+
+// replaceProperNouns calls replace for each proper noun in line with the
+// text preceding it, substituting the returned placeholder
+func replaceProperNouns(line string, replace func(name, before string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range properNounPattern.FindAllStringIndex(line, -1) {
+		b.WriteString(line[last:loc[0]])
+		b.WriteString(replace(line[loc[0]:loc[1]], line[:loc[0]]))
+		last = loc[1]
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// Deanonymize restores the original text of every placeholder found in
+// mapping. Placeholders missing from mapping are left unchanged.
+func Deanonymize(subs []srt.Subtitle, mapping Mapping) []srt.Subtitle {
+	result := make([]srt.Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+		result[i].Text = make([]string, len(sub.Text))
+		for j, line := range sub.Text {
+			result[i].Text[j] = placeholderPattern.ReplaceAllStringFunc(line, func(placeholder string) string {
+				if original, ok := mapping[placeholder]; ok {
+					return original
+				}
+				return placeholder
+			})
+		}
+	}
+	return result
+}
+
+// LoadMapping reads a mapping written by SaveMapping
+func LoadMapping(path string) (Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var mapping Mapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+	return mapping, nil
+}
+
+// SaveMapping writes mapping to path as indented JSON
+func SaveMapping(path string, mapping Mapping) error {
+	data, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode mapping: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write mapping file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package anonymize
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// subtitles returns one subtitle per line
+func subtitles(lines ...string) []srt.Subtitle {
+	subs := make([]srt.Subtitle, len(lines))
+	for i, line := range lines {
+		subs[i] = srt.Subtitle{Index: i + 1, Text: []string{line}}
+	}
+	return subs
+}
+
+// texts returns the first text line of each subtitle
+func texts(subs []srt.Subtitle) []string {
+	lines := make([]string, len(subs))
+	for i, sub := range subs {
+		lines[i] = sub.Text[0]
+	}
+	return lines
+}
+
+func TestAnonymize(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		want    []string
+		mapping Mapping
+	}{
+		{
+			name:    "sentence-initial word dropped when the rest is known",
+			lines:   []string{"Ask John Smith.", "I met John Smith today."},
+			want:    []string{"Ask PERSON_1.", "I met PERSON_1 today."},
+			mapping: Mapping{"PERSON_1": "John Smith"},
+		},
+		{
+			name:    "dropped after a sentence ends mid-line",
+			lines:   []string{"Wait. Tell John Smith.", "I know John Smith."},
+			want:    []string{"Wait. Tell PERSON_1.", "I know PERSON_1."},
+			mapping: Mapping{"PERSON_1": "John Smith"},
+		},
+		{
+			name:    "sentence-initial word kept when the rest is not known",
+			lines:   []string{"Ask John Smith."},
+			want:    []string{"PERSON_1."},
+			mapping: Mapping{"PERSON_1": "Ask John Smith"},
+		},
+		{
+			name:    "mid-sentence sequence kept whole",
+			lines:   []string{"I saw Mary Jane Watson.", "Mary Jane Watson left.", "We met Jane Watson."},
+			want:    []string{"I saw PERSON_1.", "PERSON_1 left.", "We met PERSON_2."},
+			mapping: Mapping{"PERSON_1": "Mary Jane Watson", "PERSON_2": "Jane Watson"},
+		},
+		{
+			name:    "person and place",
+			lines:   []string{"John Smith lives in New York.", "He flew from Los Angeles to see John Smith."},
+			want:    []string{"PERSON_1 lives in PLACE_1.", "He flew from PLACE_2 to see PERSON_1."},
+			mapping: Mapping{"PERSON_1": "John Smith", "PLACE_1": "New York", "PLACE_2": "Los Angeles"},
+		},
+		{
+			name:    "single capitalized words kept",
+			lines:   []string{"Hello, John went to Paris."},
+			want:    []string{"Hello, John went to Paris."},
+			mapping: Mapping{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, mapping := Anonymize(subtitles(tt.lines...))
+			if !slices.Equal(texts(got), tt.want) {
+				t.Errorf("Anonymize() text = %q, want %q", texts(got), tt.want)
+			}
+			if !maps.Equal(mapping, tt.mapping) {
+				t.Errorf("Anonymize() mapping = %v, want %v", mapping, tt.mapping)
+			}
+
+			restored := Deanonymize(got, mapping)
+			if !slices.Equal(texts(restored), tt.lines) {
+				t.Errorf("Deanonymize() = %q, want %q", texts(restored), tt.lines)
+			}
+		})
+	}
+}
+
+func TestDeanonymizeUnknownPlaceholder(t *testing.T) {
+	subs := subtitles("PERSON_1 met PERSON_2 in PLACE_1.")
+	got := Deanonymize(subs, Mapping{"PERSON_1": "John Smith", "PLACE_1": "New York"})
+	want := []string{"John Smith met PERSON_2 in New York."}
+	if !slices.Equal(texts(got), want) {
+		t.Errorf("Deanonymize() = %q, want %q", texts(got), want)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package cache stores translated subtitle text on disk so unchanged
+// subtitles are not sent to the model again.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long entries are kept when no TTL is configured
+const DefaultTTL = 30 * 24 * time.Hour
+
+// Entry is a cached translation
+type Entry struct {
+	Translation []string  `json:"translation"`
+	Created     time.Time `json:"created"`
+}
+
+// Cache is a translation cache backed by a JSON file. It is safe for
+// concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	Hits    int               `json:"hits"`
+	Misses  int               `json:"misses"`
+	Entries map[string]*Entry `json:"entries"`
+}
+
+// DefaultPath returns the cache file in the user cache directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "srtran", "translations.json"), nil
+}
+
+// Open loads the cache at path, starting empty if it does not exist.
+// Entries older than ttl are treated as missing; 0 uses DefaultTTL.
+func Open(path string, ttl time.Duration) (*Cache, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	c := &Cache{path: path, ttl: ttl, Entries: make(map[string]*Entry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse cache: %w", err)
+	}
+	if c.Entries == nil {
+		c.Entries = make(map[string]*Entry)
+	}
+	return c, nil
+}
+
+// Key returns the cache key for the given parts, e.g. backend, model,
+// languages and source text
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached translation for key. Expired entries are
+// deleted and reported as a miss.
+func (c *Cache) Get(key string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.Entries[key]
+	if ok && time.Since(entry.Created) > c.ttl {
+		delete(c.Entries, key)
+		ok = false
+	}
+	if !ok {
+		c.Misses++
+		return nil, false
+	}
+	c.Hits++
+	return entry.Translation, true
+}
+
+// Put stores translation under key
+func (c *Cache) Put(key string, translation []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Entries[key] = &Entry{Translation: translation, Created: time.Now()}
+}
+
+// Save writes the cache to disk
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to replace cache: %w", err)
+	}
+	return nil
+}
+
+// AgeBucket counts entries created within an age range
+type AgeBucket struct {
+	Label string
+	Max   time.Duration
+	Count int
+}
+
+// AgeBuckets counts entries by age: 0-1d, 1d-7d, 7d-30d and >30d
+func (c *Cache) AgeBuckets() []AgeBucket {
+	buckets := []AgeBucket{
+		{Label: "0-1d", Max: 24 * time.Hour},
+		{Label: "1d-7d", Max: 7 * 24 * time.Hour},
+		{Label: "7d-30d", Max: 30 * 24 * time.Hour},
+		{Label: ">30d"},
+	}
+
+	for _, entry := range c.Entries {
+		age := time.Since(entry.Created)
+		for i := range buckets {
+			if buckets[i].Max == 0 || age < buckets[i].Max {
+				buckets[i].Count++
+				break
+			}
+		}
+	}
+	return buckets
+}
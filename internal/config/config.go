@@ -14,12 +14,50 @@ import (
 )
 
 type Config struct {
-	Backend   string `toml:"backend"`
-	Model     string `toml:"model"`
-	APIKey    string `toml:"api_key"`
-	BaseURL   string `toml:"base_url"`
-	RPM       int    `toml:"rpm"`
-	BatchSize int    `toml:"batch_size"`
+	Backend string `toml:"backend"`
+	Model   string `toml:"model"`
+	// ModelFallback lists models to try in order when one fails with a quota or auth error
+	ModelFallback []string `toml:"model_fallback"`
+	APIKey        string   `toml:"api_key"`
+	BaseURL       string   `toml:"base_url"`
+	RPM           int      `toml:"rpm"`
+	BurstSize     int      `toml:"burst_size"`
+	// RateLimitStrategy is "fixed" or "adaptive"
+	RateLimitStrategy string `toml:"rate_limit_strategy"`
+	BatchSize         int    `toml:"batch_size"`
+	// ProjectID and Location configure the vertexai backend
+	ProjectID string `toml:"project_id"`
+	Location  string `toml:"location"`
+	// OutputEncoding is the character encoding of written subtitle files
+	OutputEncoding string `toml:"output_encoding"`
+	// LineEnding is lf, crlf or platform
+	LineEnding string `toml:"line_ending"`
+	// OpenAIOrganization is sent as the OpenAI-Organization header
+	OpenAIOrganization string `toml:"openai_organization"`
+	// OpenRouter attribution headers
+	OpenRouterSiteURL string `toml:"openrouter_site_url"`
+	OpenRouterAppName string `toml:"openrouter_app_name"`
+	// OpenRouterProviderOrder lists the providers OpenRouter should prefer
+	OpenRouterProviderOrder []string `toml:"openrouter_provider_order"`
+	// OpenRouterTransforms lists OpenRouter's prompt transforms; ["none"]
+	// disables them
+	OpenRouterTransforms []string `toml:"openrouter_transforms"`
+	// ToneRules overrides the prompt rule used for each tone found by
+	// --tone-detection (formal, casual, humorous, dramatic)
+	ToneRules map[string]string `toml:"tone_rules"`
+	// ModelCosts maps model names to their prices, used by cost-estimate
+	ModelCosts map[string]ModelCost `toml:"model_costs"`
+	// UpdateCheck enables the startup check for newer releases
+	UpdateCheck bool `toml:"update_check"`
+	// Telemetry sends anonymous usage statistics to TelemetryURL
+	Telemetry    bool   `toml:"telemetry"`
+	TelemetryURL string `toml:"telemetry_url"`
+}
+
+// ModelCost is the price of a model in USD per million tokens
+type ModelCost struct {
+	Input  float64 `toml:"input"`
+	Output float64 `toml:"output"`
 }
 
 // configPaths returns a list of paths to check for config files
@@ -40,7 +78,9 @@ func configPaths() []string {
 
 // LoadConfig loads configuration from environment variables and config files
 func LoadConfig(configFile string) (*Config, error) {
-	config := &Config{}
+	config := &Config{
+		UpdateCheck: true,
+	}
 
 	// If config file is specified explicitly
 	if configFile != "" {
@@ -94,6 +134,9 @@ func LoadConfig(configFile string) (*Config, error) {
 				config.RPM = val
 			}
 		}
+		if organization := os.Getenv("OPENAI_ORGANIZATION"); organization != "" {
+			config.OpenAIOrganization = organization
+		}
 	} else if apiKey := os.Getenv("LMSTUDIO_API_KEY"); apiKey != "" {
 		config.Backend = "lmstudio"
 		config.APIKey = apiKey
@@ -108,6 +151,24 @@ func LoadConfig(configFile string) (*Config, error) {
 				config.RPM = val
 			}
 		}
+	} else if projectID := os.Getenv("GOOGLE_PROJECT_ID"); projectID != "" {
+		config.Backend = "vertexai"
+		config.ProjectID = projectID
+		if location := os.Getenv("GOOGLE_LOCATION"); location != "" {
+			config.Location = location
+		}
+		if model := os.Getenv("GOOGLE_AI_MODEL"); model != "" {
+			config.Model = model
+		}
+		if rpm := os.Getenv("GOOGLE_AI_RPM"); rpm != "" {
+			if val, err := strconv.Atoi(rpm); err == nil {
+				config.RPM = val
+			}
+		}
+	}
+
+	if config.Backend == "vertexai" && config.Location == "" {
+		config.Location = "us-central1"
 	}
 
 	return config, nil
@@ -0,0 +1,191 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// templateHeader starts every generated config file
+const templateHeader = `# SRTran Configuration
+#
+# Place this file at ./config.toml, ~/.config/srtran/config.toml
+# or ~/.srtran.toml, or pass it with --config.
+# Command-line flags override the values below.
+
+`
+
+// googleAITemplate configures the googleai backend
+const googleAITemplate = `# backend (string): googleai, vertexai, openai, openrouter or lmstudio
+backend = "googleai"
+
+# model (string): a Gemini model name
+model = "gemini-2.0-flash"
+
+# api_key (string): Google AI Studio API key
+api_key = "your_api_key_here"
+
+# rpm (int): maximum requests per minute, 0 for no limit (default 0)
+# The free tier allows 10-15 rpm depending on the model
+rpm = 9
+`
+
+// vertexAITemplate configures the vertexai backend
+const vertexAITemplate = `# backend (string): googleai, vertexai, openai, openrouter or lmstudio
+backend = "vertexai"
+
+# model (string): a Gemini model name
+model = "gemini-2.0-flash"
+
+# project_id (string): Google Cloud project; credentials come from
+# application default credentials (gcloud auth application-default login)
+project_id = "my-gcp-project"
+
+# location (string): Google Cloud region (default "us-central1")
+location = "us-central1"
+
+# rpm (int): maximum requests per minute, 0 for no limit (default 0)
+rpm = 0
+`
+
+// openAITemplate configures the openai backend
+const openAITemplate = `# backend (string): googleai, vertexai, openai, openrouter or lmstudio
+backend = "openai"
+
+# model (string): an OpenAI chat model name
+model = "gpt-4o"
+
+# api_key (string): OpenAI API key
+api_key = "your_api_key_here"
+
+# openai_organization (string): organization ID, for accounts that belong to more than one
+# openai_organization = "org-xxx"
+
+# rpm (int): maximum requests per minute, 0 for no limit (default 0)
+rpm = 0
+`
+
+// openRouterTemplate configures the openrouter backend
+const openRouterTemplate = `# backend (string): googleai, vertexai, openai, openrouter or lmstudio
+backend = "openrouter"
+
+# model (string): OpenRouter models are named <provider>/<model>,
+# e.g. "anthropic/claude-3.5-sonnet" or "google/gemini-2.0-flash-001"
+model = "anthropic/claude-3.5-sonnet"
+
+# api_key (string): OpenRouter API key
+api_key = "your_api_key_here"
+
+# base_url (string): OpenRouter API endpoint
+base_url = "https://openrouter.ai/api/v1"
+
+# openrouter_site_url, openrouter_app_name (string): attribution headers
+# sent as HTTP-Referer and X-Title (default SRTran's repository and name)
+# openrouter_site_url = "https://github.com/21d5/SRTran"
+# openrouter_app_name = "SRTran"
+
+# openrouter_provider_order ([]string): providers OpenRouter should try
+# first, in order (default OpenRouter's own routing)
+# openrouter_provider_order = ["Anthropic", "AWS Bedrock"]
+
+# openrouter_transforms ([]string): prompt transforms applied by OpenRouter;
+# ["none"] keeps long prompts intact instead of using middle-out
+# openrouter_transforms = ["none"]
+
+# rpm (int): maximum requests per minute, 0 for no limit (default 0)
+rpm = 0
+`
+
+// lmStudioTemplate configures the lmstudio backend
+const lmStudioTemplate = `# backend (string): googleai, vertexai, openai, openrouter or lmstudio
+backend = "lmstudio"
+
+# model (string): the name of the model loaded in LM Studio
+model = "qwen2.5-7b-instruct-1m"
+
+# base_url (string): LM Studio API endpoint (default "http://localhost:1234/v1")
+base_url = "http://localhost:1234/v1"
+
+# No API key is needed for LM Studio
+
+# rpm (int): maximum requests per minute, 0 for no limit (default 0)
+# Adjust based on your hardware
+rpm = 2
+`
+
+// commonTemplate lists the options shared by all backends
+const commonTemplate = `
+# model_fallback (list of strings): models to try in order when one fails
+# with a quota or auth error; the first entry replaces model
+# model_fallback = ["model-a", "model-b"]
+
+# burst_size (int): requests that may be sent at once before the rpm limit
+# applies (default rpm)
+# burst_size = 1
+
+# rate_limit_strategy (string): fixed spaces requests evenly at rpm (default);
+# adaptive follows the X-RateLimit-* headers of OpenAI-compatible backends,
+# between a quarter of and twice rpm
+# rate_limit_strategy = "fixed"
+
+# batch_size (int): subtitles sent per request (default 20)
+# Lower this for models with small context windows
+# batch_size = 20
+
+# output_encoding (string): utf8 (default), utf8bom, latin1 or cp1252
+# Use utf8bom for Windows Notepad and many Windows subtitle editors
+# output_encoding = "utf8"
+
+# line_ending (string): lf (default), crlf or platform (crlf on Windows)
+# line_ending = "lf"
+
+# update_check (bool): check GitHub for newer releases on startup (default true)
+# update_check = true
+
+# telemetry (bool): send anonymous usage statistics to telemetry_url after a
+# successful translation; no API keys, paths or subtitle text (default false)
+# telemetry = false
+
+# telemetry_url (string): endpoint the statistics are posted to, required
+# when telemetry is enabled
+# telemetry_url = "https://telemetry.example.com/srtran"
+
+# Tables must come after the plain options above.
+
+# [tone_rules] (table of strings): replaces the prompt rule used for each
+# tone found by --tone-detection (formal, casual, humorous, dramatic)
+# [tone_rules]
+# formal = "Use a formal register and avoid slang"
+# casual = "Use a relaxed, conversational register"
+
+# [model_costs.<model>] (table): price of a model in USD per million input
+# and output tokens, used by cost-estimate and --metadata-file
+# [model_costs."gpt-4o"]
+# input = 2.50
+# output = 10.00
+`
+
+// backendTemplates maps each backend to its config section
+var backendTemplates = map[string]string{
+	"googleai":   googleAITemplate,
+	"vertexai":   vertexAITemplate,
+	"openai":     openAITemplate,
+	"openrouter": openRouterTemplate,
+	"lmstudio":   lmStudioTemplate,
+}
+
+// Template returns a commented starter config file for backend
+func Template(backend string) (string, error) {
+	section, ok := backendTemplates[backend]
+	if !ok {
+		return "", fmt.Errorf("unsupported backend: %s", backend)
+	}
+
+	var b strings.Builder
+	b.WriteString(templateHeader)
+	b.WriteString(section)
+	b.WriteString(commonTemplate)
+	return b.String(), nil
+}
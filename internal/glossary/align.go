@@ -0,0 +1,164 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package glossary learns term translations from previously translated
+// subtitle files.
+package glossary
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/s0up4200/SRTran/internal/lang"
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// minScore is the lowest Dice coefficient at which two words are aligned
+const minScore = 0.5
+
+// MaxEntries bounds the glossary size so it does not dominate the prompt
+const MaxEntries = 50
+
+// Entry is a source word and the translation it was aligned with
+type Entry struct {
+	Source string
+	Target string
+	// Count is the number of subtitle pairs containing both words
+	Count int
+}
+
+// Align learns word translations from an original subtitle file and its
+// translation. Subtitles are paired by index and each pair's words are
+// counted as co-occurring; a source and target word are aligned when
+// each is the other's best match by Dice coefficient, they co-occur in at
+// least minCount pairs and the coefficient is at least 0.5. Stopwords,
+// numbers and words left unchanged by the translation are skipped.
+// Entries are sorted by count, most frequent first.
+func Align(original, translated []srt.Subtitle, minCount int) []Entry {
+	byIndex := make(map[int]srt.Subtitle, len(translated))
+	for _, sub := range translated {
+		byIndex[sub.Index] = sub
+	}
+
+	var sourcePairs, targetPairs [][]string
+	var sourceWords, targetWords []string
+	for _, sub := range original {
+		t, ok := byIndex[sub.Index]
+		if !ok {
+			continue
+		}
+		s, tt := words(sub.Text), words(t.Text)
+		sourcePairs = append(sourcePairs, s)
+		targetPairs = append(targetPairs, tt)
+		sourceWords = append(sourceWords, s...)
+		targetWords = append(targetWords, tt...)
+	}
+
+	sourceStop := lang.Stopwords[lang.DetectFromWords(sourceWords)]
+	targetStop := lang.Stopwords[lang.DetectFromWords(targetWords)]
+
+	sourceFreq := make(map[string]int)
+	targetFreq := make(map[string]int)
+	cooccur := make(map[[2]string]int)
+	for i := range sourcePairs {
+		sources := unique(sourcePairs[i], sourceStop)
+		targets := unique(targetPairs[i], targetStop)
+		for _, s := range sources {
+			sourceFreq[s]++
+		}
+		for _, t := range targets {
+			targetFreq[t]++
+		}
+		for _, s := range sources {
+			for _, t := range targets {
+				cooccur[[2]string{s, t}]++
+			}
+		}
+	}
+
+	// best match of each word on the other side
+	type match struct {
+		word  string
+		score float64
+		count int
+	}
+	bestTarget := make(map[string]match)
+	bestSource := make(map[string]match)
+	better := func(a, b match) bool {
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.count != b.count {
+			return a.count > b.count
+		}
+		return a.word < b.word
+	}
+	for pair, count := range cooccur {
+		s, t := pair[0], pair[1]
+		score := 2 * float64(count) / float64(sourceFreq[s]+targetFreq[t])
+		if m := (match{t, score, count}); bestTarget[s].word == "" || better(m, bestTarget[s]) {
+			bestTarget[s] = m
+		}
+		if m := (match{s, score, count}); bestSource[t].word == "" || better(m, bestSource[t]) {
+			bestSource[t] = m
+		}
+	}
+
+	var entries []Entry
+	for s, m := range bestTarget {
+		if m.count < minCount || m.score < minScore || s == m.word || bestSource[m.word].word != s {
+			continue
+		}
+		entries = append(entries, Entry{Source: s, Target: m.word, Count: m.count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Source < entries[j].Source
+	})
+	return entries
+}
+
+// Format returns up to MaxEntries entries as a single line for the prompt
+func Format(entries []Entry) string {
+	if len(entries) > MaxEntries {
+		entries = entries[:MaxEntries]
+	}
+	pairs := make([]string, len(entries))
+	for i, e := range entries {
+		pairs[i] = fmt.Sprintf("%s = %s", e.Source, e.Target)
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// words returns the lowercase words of lines, without numbers
+func words(lines []string) []string {
+	var result []string
+	for _, line := range lines {
+		for _, w := range strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+		}) {
+			w = strings.Trim(w, "'")
+			if w != "" && strings.IndexFunc(w, unicode.IsLetter) >= 0 {
+				result = append(result, w)
+			}
+		}
+	}
+	return result
+}
+
+// unique returns the distinct words that are not stopwords
+func unique(words []string, stopwords map[string]bool) []string {
+	seen := make(map[string]bool, len(words))
+	var result []string
+	for _, w := range words {
+		if !seen[w] && !stopwords[w] {
+			seen[w] = true
+			result = append(result, w)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package glossary
+
+import (
+	"testing"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+func TestAlign(t *testing.T) {
+	parser := srt.NewParser(false)
+	original, err := parser.Parse("testdata/parallel.en.srt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	translated, err := parser.Parse("testdata/parallel.de.srt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := Align(original, translated, 2)
+	got := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		got[e.Source] = e
+	}
+
+	for _, want := range []Entry{
+		{Source: "captain", Target: "kapitän", Count: 3},
+		{Source: "ship", Target: "schiff", Count: 3},
+		{Source: "water", Target: "wasser", Count: 3},
+		{Source: "coffee", Target: "kaffee", Count: 3},
+	} {
+		if e, ok := got[want.Source]; !ok || e != want {
+			t.Errorf("entry for %q = %+v, want %+v", want.Source, e, want)
+		}
+	}
+
+	// seen once, below minCount
+	if e, ok := got["cold"]; ok {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	// left unchanged by the translation
+	if e, ok := got["bring"]; ok {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	// stopwords
+	for _, word := range []string{"the", "der"} {
+		for _, e := range entries {
+			if e.Source == word || e.Target == word {
+				t.Errorf("unexpected stopword entry %+v", e)
+			}
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	entries := []Entry{
+		{Source: "captain", Target: "kapitän", Count: 3},
+		{Source: "ship", Target: "schiff", Count: 2},
+	}
+	if got, want := Format(entries), "captain = kapitän; ship = schiff"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+
+	many := make([]Entry, MaxEntries+10)
+	for i := range many {
+		many[i] = Entry{Source: "a", Target: "b"}
+	}
+	if got := Format(many); len(got) != MaxEntries*len("a = b")+(MaxEntries-1)*len("; ") {
+		t.Errorf("Format() did not limit the entries to %d: %q", MaxEntries, got)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package grpc serves the SRTranService defined in api/srtran.proto.
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/s0up4200/SRTran/api"
+	"github.com/s0up4200/SRTran/internal/srt"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server translates subtitles for SRTranService clients
+type Server struct {
+	api.UnimplementedSRTranServiceServer
+
+	defaults translate.ServiceConfig
+	logger   zerolog.Logger
+
+	// mu guards shared, the service of requests that use the defaults.
+	// Sharing it makes those requests share its rate limiter.
+	mu     sync.Mutex
+	shared *translate.Service
+}
+
+// NewServer creates a server that translates with defaults. The backend
+// settings of a request override them for that request.
+func NewServer(defaults translate.ServiceConfig, logger zerolog.Logger) *Server {
+	return &Server{defaults: defaults, logger: logger}
+}
+
+// Serve serves SRTranService on lis until ctx is done, then stops
+// gracefully, letting running translations finish
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	server := grpc.NewServer()
+	api.RegisterSRTranServiceServer(server, s)
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.GracefulStop()
+		case <-stopped:
+		}
+	}()
+
+	return server.Serve(lis)
+}
+
+// Translate translates the SRT file of req and streams back each subtitle
+// block as its batch is translated
+func (s *Server) Translate(req *api.TranslateRequest, stream api.SRTranService_TranslateServer) error {
+	if req.GetSourceLanguage() == "" || req.GetTargetLanguage() == "" {
+		return status.Error(codes.InvalidArgument, "source and target language are required")
+	}
+
+	subtitles, err := srt.NewParser(false).ParseReader(bytes.NewReader(req.GetSrt()))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to parse SRT: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	config := s.serviceConfig(req.GetBackend())
+	service, err := s.service(req.GetBackend(), config)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to initialize translation service: %v", err)
+	}
+
+	// blocks carries the subtitles of each finished batch to the stream
+	blocks := make(chan srt.Subtitle)
+	ctx = translate.WithOnBatch(ctx, func(progress translate.BatchProgress) {
+		for _, sub := range progress.Subtitles {
+			select {
+			case blocks <- sub:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	s.logger.Info().
+		Str("backend", string(config.Backend)).
+		Int("subtitles", len(subtitles)).
+		Str("target", req.GetTargetLanguage()).
+		Msg("translation requested")
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := service.Translate(ctx, subtitles, req.GetSourceLanguage(), req.GetTargetLanguage())
+		close(blocks)
+		errc <- err
+	}()
+
+	var sendErr error
+	for sub := range blocks {
+		if sendErr != nil {
+			continue
+		}
+		if sendErr = stream.Send(&api.TranslateResponse{Block: subtitleBlock(sub)}); sendErr != nil {
+			// stop translating, then drain until the service returns
+			cancel()
+		}
+	}
+	err = <-errc
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "translation failed: %v", err)
+	}
+	return nil
+}
+
+// service returns the translation service for a request with the backend
+// settings of backend, which serviceConfig turned into config. Requests
+// that use the defaults share one service, so the RPM limit applies to
+// all of them together rather than to each request.
+func (s *Server) service(backend *api.BackendConfig, config translate.ServiceConfig) (*translate.Service, error) {
+	if !s.usesDefaults(backend) {
+		return translate.NewService(config)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shared == nil {
+		service, err := translate.NewService(config)
+		if err != nil {
+			return nil, err
+		}
+		s.shared = service
+	}
+	return s.shared, nil
+}
+
+// usesDefaults reports whether backend leaves the defaults unchanged
+func (s *Server) usesDefaults(backend *api.BackendConfig) bool {
+	if backend == nil {
+		return true
+	}
+	b := translate.Backend(backend.GetBackend())
+	return (b == "" || b == s.defaults.Backend) &&
+		backend.GetBaseUrl() == "" &&
+		backend.GetApiKey() == "" &&
+		backend.GetModel() == "" &&
+		backend.GetRpm() <= 0
+}
+
+// serviceConfig returns the defaults with the settings of backend applied.
+// A request that picks another backend or base URL must bring its own
+// API key, so the server's key is never sent to a host the client chose.
+func (s *Server) serviceConfig(backend *api.BackendConfig) translate.ServiceConfig {
+	config := s.defaults
+	if backend == nil {
+		return config
+	}
+
+	if b := translate.Backend(backend.GetBackend()); b != "" && b != config.Backend {
+		config.Backend = b
+		config.APIKey = ""
+		config.BaseURL = ""
+		if b == translate.BackendOpenRouter {
+			config.BaseURL = translate.DefaultOpenRouterBaseURL
+		}
+	}
+	if url := backend.GetBaseUrl(); url != "" {
+		config.APIKey = ""
+		config.BaseURL = url
+	}
+	if key := backend.GetApiKey(); key != "" {
+		config.APIKey = key
+	}
+	if model := backend.GetModel(); model != "" {
+		config.Model = model
+		config.ModelFallback = nil
+	}
+	if rpm := backend.GetRpm(); rpm > 0 {
+		config.RPM = int(rpm)
+	}
+	return config
+}
+
+// subtitleBlock converts sub to its protocol message
+func subtitleBlock(sub srt.Subtitle) *api.SubtitleBlock {
+	return &api.SubtitleBlock{
+		Index:      int32(sub.Index),
+		Start:      sub.Start,
+		End:        sub.End,
+		Text:       sub.Text,
+		Translated: sub.Translated,
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/s0up4200/SRTran/api"
+	"github.com/s0up4200/SRTran/internal/translate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// batchText matches the subtitle blocks at the end of a prompt
+var batchText = regexp.MustCompile(`(?s)Here are the subtitles to translate:\n\n(.*)$`)
+
+// newMockOpenAI starts a chat completions server that "translates" every
+// subtitle block of a prompt to upper case
+func newMockOpenAI(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		m := batchText.FindStringSubmatch(req.Messages[len(req.Messages)-1].Content)
+		if m == nil {
+			http.Error(w, "no subtitles in prompt", http.StatusBadRequest)
+			return
+		}
+
+		var blocks []string
+		for _, block := range strings.Split(strings.TrimSpace(m[1]), "\n===SUBTITLE===\n") {
+			marker, text, _ := strings.Cut(block, "\n")
+			blocks = append(blocks, marker+"\n"+strings.ToUpper(text))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{
+				"finish_reason": "stop",
+				"message": map[string]string{
+					"role":    "assistant",
+					"content": strings.Join(blocks, "\n===SUBTITLE===\n"),
+				},
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newClient serves s over an in-memory listener and returns a client
+func newClient(t *testing.T, s *Server) api.SRTranServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, lis) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return api.NewSRTranServiceClient(conn)
+}
+
+func TestTranslateStreamsBlocks(t *testing.T) {
+	nop := zerolog.Nop()
+	openai := newMockOpenAI(t)
+	client := newClient(t, NewServer(translate.ServiceConfig{
+		APIKey:    "test",
+		BaseURL:   openai.URL,
+		Model:     "gpt-4o-mini",
+		Backend:   translate.BackendOpenAI,
+		BatchSize: 2,
+		Logger:    &nop,
+	}, nop))
+
+	var content strings.Builder
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&content, "%d\n00:00:%02d,000 --> 00:00:%02d,500\nline %d\n\n", i, i, i, i)
+	}
+
+	stream, err := client.Translate(context.Background(), &api.TranslateRequest{
+		Srt:            []byte(content.String()),
+		SourceLanguage: "english",
+		TargetLanguage: "german",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var blocks []*api.SubtitleBlock
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		blocks = append(blocks, resp.GetBlock())
+	}
+
+	if len(blocks) != 5 {
+		t.Fatalf("received %d blocks, want 5", len(blocks))
+	}
+	for i, block := range blocks {
+		want := fmt.Sprintf("LINE %d", i+1)
+		if block.GetIndex() != int32(i+1) || strings.Join(block.GetTranslated(), "\n") != want {
+			t.Errorf("block %d = %d %q, want %d %q", i+1, block.GetIndex(), block.GetTranslated(), i+1, want)
+		}
+		if got := strings.Join(block.GetText(), "\n"); got != fmt.Sprintf("line %d", i+1) {
+			t.Errorf("block %d text = %q", i+1, got)
+		}
+	}
+}
+
+func TestTranslateInvalidRequest(t *testing.T) {
+	client := newClient(t, NewServer(translate.ServiceConfig{
+		APIKey:  "test",
+		Backend: translate.BackendOpenAI,
+	}, zerolog.Nop()))
+
+	tests := []struct {
+		name string
+		req  *api.TranslateRequest
+	}{
+		{
+			name: "missing language",
+			req:  &api.TranslateRequest{Srt: []byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), SourceLanguage: "english"},
+		},
+		{
+			name: "invalid srt",
+			req:  &api.TranslateRequest{Srt: []byte("not a subtitle file"), SourceLanguage: "english", TargetLanguage: "german"},
+		},
+		{
+			name: "other backend without api key",
+			req: &api.TranslateRequest{
+				Srt:            []byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"),
+				SourceLanguage: "english",
+				TargetLanguage: "german",
+				Backend:        &api.BackendConfig{Backend: "openrouter"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.Translate(context.Background(), tt.req)
+			if err == nil {
+				_, err = stream.Recv()
+			}
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Translate() error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestServiceConfigKeepsKeyOnDefaultHost(t *testing.T) {
+	s := NewServer(translate.ServiceConfig{APIKey: "server-key", Backend: translate.BackendOpenAI}, zerolog.Nop())
+
+	if got := s.serviceConfig(&api.BackendConfig{Model: "gpt-4o"}); got.APIKey != "server-key" || got.Model != "gpt-4o" {
+		t.Errorf("model override: APIKey = %q, Model = %q", got.APIKey, got.Model)
+	}
+	if got := s.serviceConfig(&api.BackendConfig{BaseUrl: "https://example.com/v1"}); got.APIKey != "" {
+		t.Errorf("base URL override kept the server key")
+	}
+	if got := s.serviceConfig(&api.BackendConfig{Backend: "openrouter", ApiKey: "client-key"}); got.APIKey != "client-key" || got.BaseURL != translate.DefaultOpenRouterBaseURL {
+		t.Errorf("backend override: APIKey = %q, BaseURL = %q", got.APIKey, got.BaseURL)
+	}
+}
+
+func TestServiceSharedForDefaults(t *testing.T) {
+	s := NewServer(translate.ServiceConfig{APIKey: "server-key", Backend: translate.BackendOpenAI, RPM: 60}, zerolog.Nop())
+
+	service := func(backend *api.BackendConfig) *translate.Service {
+		t.Helper()
+		service, err := s.service(backend, s.serviceConfig(backend))
+		if err != nil {
+			t.Fatalf("service() error = %v", err)
+		}
+		return service
+	}
+
+	shared := service(nil)
+	if got := service(&api.BackendConfig{Backend: "openai"}); got != shared {
+		t.Errorf("request with the default backend did not share the service")
+	}
+	if got := service(&api.BackendConfig{Model: "gpt-4o"}); got == shared {
+		t.Errorf("request with its own model shared the default service")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package lang lists common languages, their stopwords and helpers to
+// recognise them in file names and text.
+package lang
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Language is a language name with its ISO 639-1 code
+type Language struct {
+	Name string
+	Code string
+}
+
+// Common lists frequently used subtitle languages
+var Common = []Language{
+	{"arabic", "ar"},
+	{"bulgarian", "bg"},
+	{"chinese", "zh"},
+	{"croatian", "hr"},
+	{"czech", "cs"},
+	{"danish", "da"},
+	{"dutch", "nl"},
+	{"english", "en"},
+	{"estonian", "et"},
+	{"finnish", "fi"},
+	{"french", "fr"},
+	{"german", "de"},
+	{"greek", "el"},
+	{"hebrew", "he"},
+	{"hindi", "hi"},
+	{"hungarian", "hu"},
+	{"icelandic", "is"},
+	{"indonesian", "id"},
+	{"italian", "it"},
+	{"japanese", "ja"},
+	{"korean", "ko"},
+	{"latvian", "lv"},
+	{"lithuanian", "lt"},
+	{"malay", "ms"},
+	{"norwegian", "no"},
+	{"persian", "fa"},
+	{"polish", "pl"},
+	{"portuguese", "pt"},
+	{"romanian", "ro"},
+	{"russian", "ru"},
+	{"serbian", "sr"},
+	{"slovak", "sk"},
+	{"slovenian", "sl"},
+	{"spanish", "es"},
+	{"swedish", "sv"},
+	{"thai", "th"},
+	{"turkish", "tr"},
+	{"ukrainian", "uk"},
+	{"vietnamese", "vi"},
+}
+
+// Completions returns every name and code in Common, each followed by a tab
+// and the other form as a description, as expected by shell completion
+func Completions() []string {
+	completions := make([]string, 0, 2*len(Common))
+	for _, l := range Common {
+		completions = append(completions, l.Name+"\t"+l.Code, l.Code+"\t"+l.Name)
+	}
+	return completions
+}
+
+// FromFilename returns the language named by the last extension before
+// the file's own, as in movie.en.srt or movie.english.srt
+func FromFilename(path string) (Language, bool) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	tag := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	if tag == "" {
+		return Language{}, false
+	}
+	for _, l := range Common {
+		if tag == l.Code || tag == l.Name {
+			return l, true
+		}
+	}
+	return Language{}, false
+}
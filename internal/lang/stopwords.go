@@ -0,0 +1,47 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package lang
+
+// Stopwords maps ISO 639-1 codes to common function words, which are
+// excluded from word counts and used to guess the language of a text
+var Stopwords = map[string]map[string]bool{
+	"en": set("a", "about", "all", "an", "and", "are", "as", "at", "be", "been", "but", "by", "can", "do", "for", "from", "had", "has", "have", "he", "her", "him", "his", "i", "if", "in", "is", "it", "just", "me", "my", "no", "not", "of", "on", "or", "our", "she", "so", "that", "the", "their", "them", "there", "they", "this", "to", "up", "was", "we", "were", "what", "when", "who", "will", "with", "you", "your"),
+	"es": set("a", "al", "algo", "como", "con", "de", "del", "el", "ella", "en", "es", "esa", "ese", "esta", "este", "esto", "fue", "ha", "la", "las", "le", "lo", "los", "me", "mi", "muy", "no", "nos", "o", "para", "pero", "por", "que", "se", "si", "sin", "su", "sus", "te", "tu", "un", "una", "y", "ya", "yo"),
+	"fr": set("a", "au", "aux", "avec", "ce", "ces", "dans", "de", "des", "du", "elle", "en", "est", "et", "il", "je", "la", "le", "les", "leur", "lui", "ma", "mais", "me", "mon", "ne", "nous", "on", "ou", "par", "pas", "pour", "qu", "que", "qui", "sa", "se", "son", "sur", "ta", "te", "tu", "un", "une", "vous", "y"),
+	"de": set("aber", "als", "am", "an", "auf", "aus", "bei", "bin", "bist", "das", "dass", "dem", "den", "der", "des", "die", "du", "ein", "eine", "einen", "er", "es", "für", "hat", "ich", "ihr", "im", "in", "ist", "ja", "mich", "mir", "mit", "nicht", "noch", "nur", "sie", "sind", "so", "und", "von", "war", "was", "wir", "zu"),
+	"it": set("a", "al", "alla", "che", "chi", "ci", "come", "con", "da", "del", "della", "di", "e", "è", "gli", "ha", "i", "il", "in", "io", "la", "le", "lo", "ma", "mi", "mio", "ne", "no", "non", "per", "più", "se", "si", "sono", "su", "ti", "tu", "un", "una", "uno"),
+	"pt": set("a", "ao", "as", "com", "como", "da", "de", "do", "dos", "e", "ela", "ele", "em", "é", "eu", "isso", "isto", "já", "lhe", "mais", "mas", "me", "meu", "na", "não", "no", "o", "os", "para", "por", "que", "se", "sem", "seu", "sua", "te", "um", "uma", "você"),
+	"nl": set("aan", "al", "als", "bij", "dat", "de", "die", "dit", "een", "en", "er", "het", "hij", "hem", "ik", "in", "is", "je", "jij", "maar", "me", "met", "mij", "niet", "nog", "of", "om", "op", "te", "uit", "van", "voor", "was", "wat", "we", "wij", "ze", "zich", "zijn"),
+	"no": set("alle", "at", "av", "da", "de", "deg", "den", "der", "det", "du", "eg", "en", "er", "et", "for", "fra", "han", "hun", "i", "ikke", "jeg", "kan", "med", "meg", "men", "min", "nå", "og", "om", "på", "seg", "sin", "skal", "som", "til", "var", "vi", "å"),
+	"sv": set("att", "av", "de", "dem", "den", "det", "du", "en", "ett", "för", "har", "han", "hon", "i", "inte", "jag", "kan", "med", "men", "mig", "min", "nu", "och", "om", "på", "sig", "som", "så", "till", "var", "vi", "är"),
+	"da": set("af", "at", "de", "dem", "den", "der", "det", "dig", "du", "en", "er", "et", "for", "fra", "han", "hun", "i", "ikke", "jeg", "kan", "med", "mig", "min", "nu", "og", "om", "på", "sig", "som", "så", "til", "var", "vi"),
+}
+
+// set builds a lookup set from words
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
+
+// DetectFromWords returns the code of the Stopwords language with the most
+// stopwords in words, which must be lowercase. It returns "" if none match.
+func DetectFromWords(words []string) string {
+	best, bestHits := "", 0
+	for code, stopwords := range Stopwords {
+		hits := 0
+		for _, w := range words {
+			if stopwords[w] {
+				hits++
+			}
+		}
+		// break ties by code so the result does not depend on map order
+		if hits > bestHits || (hits == bestHits && hits > 0 && code < best) {
+			best, bestHits = code, hits
+		}
+	}
+	return best
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package media inspects audio and video files with ffmpeg.
+package media
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval is a span of time in a media file
+type Interval struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Overlaps reports whether the interval overlaps [start, end]
+func (i Interval) Overlaps(start, end time.Duration) bool {
+	return i.Start <= end && i.End >= start
+}
+
+var silencePattern = regexp.MustCompile(`silence_(start|end): (-?[0-9.]+)`)
+
+// DetectSilence returns the intervals of input quieter than noise (e.g. "-30dB")
+// for at least minDuration, using ffmpeg's silencedetect filter
+func DetectSilence(ctx context.Context, input, noise string, minDuration time.Duration) ([]Interval, error) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return nil, fmt.Errorf("ffmpeg not found in PATH: %w", err)
+	}
+
+	filter := fmt.Sprintf("silencedetect=noise=%s:d=%g", noise, minDuration.Seconds())
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-hide_banner", "-nostats",
+		"-i", input,
+		"-vn", "-af", filter,
+		"-f", "null", "-",
+	)
+	// silencedetect reports on stderr
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, string(out))
+	}
+
+	return parseSilenceDetect(string(out))
+}
+
+// parseSilenceDetect parses silencedetect output. A silence still open at
+// the end of the file extends indefinitely.
+func parseSilenceDetect(output string) ([]Interval, error) {
+	var intervals []Interval
+	var current *Interval
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := silencePattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		seconds, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid silencedetect time %q: %w", match[2], err)
+		}
+		ts := time.Duration(math.Max(seconds, 0) * float64(time.Second))
+
+		switch match[1] {
+		case "start":
+			current = &Interval{Start: ts}
+		case "end":
+			if current != nil {
+				current.End = ts
+				intervals = append(intervals, *current)
+				current = nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read silencedetect output: %w", err)
+	}
+
+	if current != nil {
+		current.End = time.Duration(math.MaxInt64)
+		intervals = append(intervals, *current)
+	}
+	return intervals, nil
+}
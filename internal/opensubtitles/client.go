@@ -0,0 +1,252 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package opensubtitles searches and downloads subtitles from the
+// OpenSubtitles.com REST API.
+package opensubtitles
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// baseURL is the OpenSubtitles.com REST API endpoint
+const baseURL = "https://api.opensubtitles.com/api/v1"
+
+// minInterval spaces requests to stay under the API limit of 10 per second
+const minInterval = time.Second / 10
+
+// requestTimeout bounds each request, including reading the response
+const requestTimeout = 30 * time.Second
+
+// maxResponseSize bounds API responses and downloaded subtitle files,
+// which are far smaller in practice
+const maxResponseSize = 10 << 20
+
+// Client is an OpenSubtitles.com API client. It is safe for concurrent use.
+type Client struct {
+	apiKey     string
+	userAgent  string
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// NewClient creates a client authenticating with apiKey. The API rejects
+// requests without a User-Agent naming the application and its version.
+func NewClient(apiKey, userAgent string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		userAgent:  userAgent,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// SearchParams narrows a subtitle search
+type SearchParams struct {
+	Query string
+	// Year is the release year, or 0 for any
+	Year int
+	// Language is an ISO 639-1 code, or "" for any
+	Language string
+}
+
+// Subtitle is a search result
+type Subtitle struct {
+	FileID        int
+	FileName      string
+	Language      string
+	Release       string
+	DownloadCount int
+}
+
+// searchResponse is the subset of the search response we care about
+type searchResponse struct {
+	Data []struct {
+		Attributes struct {
+			Language      string `json:"language"`
+			Release       string `json:"release"`
+			DownloadCount int    `json:"download_count"`
+			Files         []struct {
+				FileID   int    `json:"file_id"`
+				FileName string `json:"file_name"`
+			} `json:"files"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// Search returns the subtitles matching params in the order the API ranks
+// them. Results with several files (such as CD1/CD2 releases) are
+// represented by their first file.
+func (c *Client) Search(ctx context.Context, params SearchParams) ([]Subtitle, error) {
+	query := url.Values{}
+	query.Set("query", params.Query)
+	if params.Year > 0 {
+		query.Set("year", strconv.Itoa(params.Year))
+	}
+	if params.Language != "" {
+		query.Set("languages", params.Language)
+	}
+
+	body, err := c.do(ctx, "GET", baseURL+"/subtitles?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp searchResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+
+	var subtitles []Subtitle
+	for _, d := range resp.Data {
+		if len(d.Attributes.Files) == 0 {
+			continue
+		}
+		subtitles = append(subtitles, Subtitle{
+			FileID:        d.Attributes.Files[0].FileID,
+			FileName:      d.Attributes.Files[0].FileName,
+			Language:      d.Attributes.Language,
+			Release:       d.Attributes.Release,
+			DownloadCount: d.Attributes.DownloadCount,
+		})
+	}
+	return subtitles, nil
+}
+
+// Download returns the contents of the subtitle file, decompressed if the
+// server sent it gzipped
+func (c *Client) Download(ctx context.Context, fileID int) ([]byte, error) {
+	reqBody, err := json.Marshal(map[string]int{"file_id": fileID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal download request: %w", err)
+	}
+
+	body, err := c.do(ctx, "POST", baseURL+"/download", reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Link string `json:"link"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse download response: %w", err)
+	}
+	if resp.Link == "" {
+		return nil, fmt.Errorf("download response has no link")
+	}
+
+	// the link points at a file server, which must not get the API key
+	req, err := http.NewRequestWithContext(ctx, "GET", resp.Link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	data, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	return gunzip(data)
+}
+
+// do sends a rate limited API request and returns the response body
+func (c *Client) do(ctx context.Context, method, endpoint string, body []byte) ([]byte, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Api-Key", c.apiKey)
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(req)
+}
+
+// send sends req and returns the response body, failing on a status other
+// than 200 or a body larger than maxResponseSize
+func (c *Client) send(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := readLimited(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(data))
+	}
+	return data, nil
+}
+
+// wait blocks until minInterval has passed since the previous request
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	next := c.last.Add(minInterval)
+	now := time.Now()
+	if next.Before(now) {
+		next = now
+	}
+	c.last = next
+	c.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(next)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// gunzip decompresses data if it starts with the gzip magic number and
+// returns it unchanged otherwise
+func gunzip(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress subtitle: %w", err)
+	}
+	defer r.Close()
+
+	out, err := readLimited(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress subtitle: %w", err)
+	}
+	return out, nil
+}
+
+// readLimited reads r to the end, failing if it holds more than
+// maxResponseSize bytes
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("larger than %d bytes", maxResponseSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package plugin installs and runs prompt plugins. A plugin is an
+// executable that reads a Request as JSON on stdin and writes a Response
+// as JSON on stdout.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// runTimeout bounds a single plugin run
+const runTimeout = 30 * time.Second
+
+// Request is sent to a plugin on stdin
+type Request struct {
+	SourceLang string `json:"source_lang"`
+	TargetLang string `json:"target_lang"`
+	// Prompt is the prompt SRTran would send to the model
+	Prompt string `json:"prompt"`
+}
+
+// Response is read from a plugin's stdout
+type Response struct {
+	// Prompt replaces the request prompt
+	Prompt string `json:"prompt"`
+}
+
+// Dir returns the directory plugins are installed in
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "srtran", "plugins"), nil
+}
+
+// ModulePath turns a GitHub URL such as https://github.com/user/plugin
+// into a module path for go install, adding @latest if no version is given
+func ModulePath(url string) (string, error) {
+	path := strings.TrimSuffix(url, ".git")
+	path = strings.TrimPrefix(path, "https://")
+	path = strings.TrimPrefix(path, "http://")
+	path = strings.TrimSuffix(path, "/")
+	if !strings.HasPrefix(path, "github.com/") || strings.Count(path, "/") < 2 {
+		return "", fmt.Errorf("not a GitHub repository URL: %s", url)
+	}
+	if !strings.Contains(path, "@") {
+		path += "@latest"
+	}
+	return path, nil
+}
+
+// Install builds the plugin at url with go install into Dir
+func Install(ctx context.Context, url string) error {
+	module, err := ModulePath(url)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		return fmt.Errorf("go not found in PATH: %w", err)
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "go", "install", module)
+	cmd.Env = append(os.Environ(), "GOBIN="+dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go install failed: %w: %s", err, string(out))
+	}
+	return nil
+}
+
+// List returns the names of the installed plugins
+func List() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// path returns the installed binary of the plugin called name
+func path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid plugin name: %q", name)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Check returns an error unless the plugin called name is installed
+func Check(name string) error {
+	p, err := path(name)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(p)
+	if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("plugin %s is not installed", name)
+	}
+	return nil
+}
+
+// Remove deletes the plugin called name
+func Remove(name string) error {
+	p, err := path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("plugin %s is not installed", name)
+		}
+		return fmt.Errorf("failed to remove plugin: %w", err)
+	}
+	return nil
+}
+
+// Run sends req to the plugin called name and returns its response
+func Run(ctx context.Context, name string, req Request) (Response, error) {
+	p, err := path(name)
+	if err != nil {
+		return Response{}, err
+	}
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return Response{}, fmt.Errorf("plugin %s failed: %w: %s", name, err, stderr.String())
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return Response{}, fmt.Errorf("failed to decode plugin %s response: %w", name, err)
+	}
+	if strings.TrimSpace(resp.Prompt) == "" {
+		return Response{}, fmt.Errorf("plugin %s returned an empty prompt", name)
+	}
+	return resp, nil
+}
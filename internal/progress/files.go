@@ -0,0 +1,97 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package progress
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// barWidth is the number of characters in the aggregate progress bar
+const barWidth = 20
+
+// Files writes a progress line for files translated at once, with a bar
+// for the aggregate completion and the percentage of each file in
+// progress. It is safe for concurrent use.
+type Files struct {
+	mu    sync.Mutex
+	w     io.Writer
+	names []string
+	// done and total count the subtitles of each file; total is 0 until
+	// the first batch of the file is reported
+	done     []int
+	total    []int
+	finished []bool
+}
+
+// NewFiles returns a Files writing to w for the files named by paths
+func NewFiles(w io.Writer, paths []string) *Files {
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = filepath.Base(path)
+	}
+	return &Files{
+		w:        w,
+		names:    names,
+		done:     make([]int, len(paths)),
+		total:    make([]int, len(paths)),
+		finished: make([]bool, len(paths)),
+	}
+}
+
+// Update records that done of the total subtitles of the file numbered
+// file, counting from 0, are translated and writes the progress line
+func (f *Files) Update(file, done, total int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.done[file] = done
+	f.total[file] = total
+	f.write()
+}
+
+// Finish marks the file numbered file as complete and writes the
+// progress line
+func (f *Files) Finish(file int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.finished[file] = true
+	f.write()
+}
+
+// write writes the progress line, e.g.
+// "[=========>          ] 47% | 2/5 files | a.srt 80% | b.srt 10%"
+func (f *Files) write() {
+	var sum float64
+	var finished int
+	var inProgress []string
+	for i := range f.names {
+		switch {
+		case f.finished[i]:
+			sum++
+			finished++
+		case f.total[i] > 0:
+			fraction := float64(f.done[i]) / float64(f.total[i])
+			sum += fraction
+			inProgress = append(inProgress, fmt.Sprintf("%s %d%%", f.names[i], int(fraction*100)))
+		}
+	}
+	percent := int(sum / float64(len(f.names)) * 100)
+
+	filled := percent * barWidth / 100
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+
+	line := fmt.Sprintf("[%s] %d%% | %d/%d files", bar, percent, finished, len(f.names))
+	for _, file := range inProgress {
+		line += " | " + file
+	}
+	fmt.Fprintln(f.w, line)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package progress writes translation progress reports for CI systems
+// and the terminal.
+package progress
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// row is a finished batch in the progress table
+type row struct {
+	Batch     int
+	Done      int
+	Remaining int
+	ETA       time.Duration
+	Status    string
+}
+
+// Markdown writes a GitHub-Flavored Markdown progress table with a row per
+// finished batch, e.g. to $GITHUB_STEP_SUMMARY. It is safe for concurrent
+// use.
+type Markdown struct {
+	mu    sync.Mutex
+	path  string
+	start time.Time
+	rows  []row
+}
+
+// NewMarkdown returns a Markdown report written to path, measuring the
+// ETA from now
+func NewMarkdown(path string) *Markdown {
+	return &Markdown{path: path, start: time.Now()}
+}
+
+// Update adds a row for a finished batch and rewrites the file. batch is
+// numbered from 1, done and total count subtitles and status describes
+// the batch, e.g. "ok" or "failed".
+func (m *Markdown) Update(batch, done, total int, status string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var eta time.Duration
+	if done > 0 {
+		elapsed := time.Since(m.start)
+		eta = elapsed * time.Duration(total-done) / time.Duration(done)
+	}
+	m.rows = append(m.rows, row{
+		Batch:     batch,
+		Done:      done,
+		Remaining: total - done,
+		ETA:       eta.Round(time.Second),
+		Status:    status,
+	})
+	return m.write()
+}
+
+// write replaces the file with the current table
+func (m *Markdown) write() error {
+	var b strings.Builder
+	b.WriteString("## Translation progress\n\n")
+	b.WriteString("| Batch | Subtitles Done | Remaining | ETA | Status |\n")
+	b.WriteString("| ---: | ---: | ---: | ---: | --- |\n")
+	for _, r := range m.rows {
+		fmt.Fprintf(&b, "| %d | %d | %d | %s | %s |\n", r.Batch, r.Done, r.Remaining, r.ETA, r.Status)
+	}
+
+	// write to a temporary file and rename it so readers never see a
+	// partly written table
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write progress report: %w", err)
+	}
+	if err := os.Rename(tmp, m.path); err != nil {
+		return fmt.Errorf("failed to replace progress report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package report writes HTML summaries and JSON metadata of translation
+// runs.
+package report
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// Summary describes a translation run
+type Summary struct {
+	Backend      string
+	Model        string
+	Source       string
+	Target       string
+	Duration     time.Duration
+	InputTokens  int
+	OutputTokens int
+}
+
+// lengthRatioLimit is how much longer or shorter than the original a
+// translation can be before it is highlighted
+const lengthRatioLimit = 2.0
+
+// row is a subtitle block in the report table
+type row struct {
+	Index      int
+	Start      string
+	End        string
+	Original   string
+	Translated string
+	// Similar is false when the translation is much shorter or longer
+	Similar bool
+}
+
+var page = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>SRTran report: {{.Filename}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+.card { display: inline-block; padding: 1em 1.5em; border: 1px solid #ccc; border-radius: 6px; margin-bottom: 1.5em; }
+.card dt { font-weight: bold; float: left; clear: left; width: 9em; }
+.card dd { margin-left: 9em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ddd; padding: 0.4em; vertical-align: top; white-space: pre-wrap; }
+tr.similar td.translated { background: #e6f4e6; }
+tr.different td.translated { background: #fdf5d4; }
+</style>
+</head>
+<body>
+<h1>{{.Filename}}</h1>
+<div class="card">
+<dl>
+<dt>Backend</dt><dd>{{.Summary.Backend}}</dd>
+<dt>Model</dt><dd>{{.Summary.Model}}</dd>
+<dt>Languages</dt><dd>{{.Summary.Source}} → {{.Summary.Target}}</dd>
+<dt>Duration</dt><dd>{{.Summary.Duration}}</dd>
+<dt>Subtitles</dt><dd>{{len .Rows}}</dd>
+<dt>Input tokens</dt><dd>~{{.Summary.InputTokens}}</dd>
+<dt>Output tokens</dt><dd>~{{.Summary.OutputTokens}}</dd>
+</dl>
+<p><a href="{{.Download}}" download="{{.Filename}}">Download translated SRT</a></p>
+</div>
+<table>
+<tr><th>#</th><th>Time</th><th>Original</th><th>Translated</th></tr>
+{{range .Rows}}<tr class="{{if .Similar}}similar{{else}}different{{end}}"><td>{{.Index}}</td><td>{{.Start}}<br>{{.End}}</td><td>{{.Original}}</td><td class="translated">{{.Translated}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes a report to path with the original and translated text
+// of each subtitle side by side. srtData is the translated file, offered
+// for download as filename.
+func WriteHTML(path string, summary Summary, subtitles []srt.Subtitle, filename string, srtData []byte) error {
+	rows := make([]row, len(subtitles))
+	for i, sub := range subtitles {
+		original := strings.Join(sub.Text, "\n")
+		translated := strings.Join(sub.Translated, "\n")
+		rows[i] = row{
+			Index:      sub.Index,
+			Start:      sub.Start,
+			End:        sub.End,
+			Original:   original,
+			Translated: translated,
+			Similar:    similarLength(original, translated),
+		}
+	}
+
+	data := struct {
+		Filename string
+		Summary  Summary
+		Rows     []row
+		Download template.URL
+	}{
+		Filename: filename,
+		Summary:  summary,
+		Rows:     rows,
+		// the content is base64 encoded by us, so it is safe to use as a URL
+		Download: template.URL("data:application/x-subrip;base64," + base64.StdEncoding.EncodeToString(srtData)),
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer file.Close()
+
+	if err := page.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// similarLength reports whether translated is within lengthRatioLimit of
+// the length of original
+func similarLength(original, translated string) bool {
+	a, b := utf8.RuneCountInString(original), utf8.RuneCountInString(translated)
+	if a == 0 || b == 0 {
+		return a == b
+	}
+	ratio := float64(b) / float64(a)
+	return ratio <= lengthRatioLimit && ratio >= 1/lengthRatioLimit
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// MetadataSchemaVersion is the version of the Metadata JSON format. Fields
+// are only added within a version; renaming or removing one needs a new
+// version.
+const MetadataSchemaVersion = 1
+
+// Metadata describes a translated file for audit trails
+type Metadata struct {
+	SchemaVersion  int       `json:"schema_version"`
+	InputFile      string    `json:"input_file"`
+	OutputFile     string    `json:"output_file"`
+	SourceLanguage string    `json:"source_language"`
+	TargetLanguage string    `json:"target_language"`
+	Backend        string    `json:"backend"`
+	Model          string    `json:"model"`
+	TranslatedAt   time.Time `json:"translated_at"`
+	Subtitles      int       `json:"subtitles"`
+	// InputTokens and OutputTokens are estimated from the subtitle text
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+	// TotalCost is in USD, or nil when the model has no price configured
+	TotalCost           *float64 `json:"total_cost"`
+	AverageBatchSeconds float64  `json:"average_batch_seconds"`
+	Retries             int      `json:"retries"`
+	SRTranVersion       string   `json:"srtran_version"`
+}
+
+// WriteMetadata writes m to path as indented JSON, setting SchemaVersion
+func WriteMetadata(path string, m Metadata) error {
+	m.SchemaVersion = MetadataSchemaVersion
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write metadata file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// assStylesHeader is the section header of the ASS styles block
+const assStylesHeader = "[V4+ Styles]"
+
+// defaultASSStyles is the single-style section used when no template is given
+const defaultASSStyles = `[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,20,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,0,0,0,0,100,100,0,0,1,2,2,2,10,10,10,1
+`
+
+// assTagReplacer converts SRT formatting tags to ASS override tags
+var assTagReplacer = strings.NewReplacer(
+	"<i>", `{\i1}`, "</i>", `{\i0}`,
+	"<b>", `{\b1}`, "</b>", `{\b0}`,
+	"<u>", `{\u1}`, "</u>", `{\u0}`,
+)
+
+// ASSWriter writes subtitles in Advanced SubStation Alpha format
+type ASSWriter struct {
+	// StylesSection replaces the generated [V4+ Styles] section when non-empty
+	StylesSection string
+}
+
+// Write writes the subtitles to w. Translated text is used when present.
+func (aw *ASSWriter) Write(w io.Writer, subtitles []Subtitle) error {
+	styles := aw.StylesSection
+	if styles == "" {
+		styles = defaultASSStyles
+	}
+
+	style := assStyleName(styles)
+
+	writer := bufio.NewWriter(w)
+
+	header := "[Script Info]\n" +
+		"; Script generated by SRTran\n" +
+		"ScriptType: v4.00+\n" +
+		"PlayResX: 384\n" +
+		"PlayResY: 288\n" +
+		"WrapStyle: 0\n" +
+		"ScaledBorderAndShadow: yes\n\n"
+	if _, err := io.WriteString(writer, header); err != nil {
+		return fmt.Errorf("failed to write script info: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(writer, "%s\n", strings.TrimRight(styles, "\n")); err != nil {
+		return fmt.Errorf("failed to write styles: %w", err)
+	}
+	if _, err := io.WriteString(writer, "\n[Events]\nFormat: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n"); err != nil {
+		return fmt.Errorf("failed to write events header: %w", err)
+	}
+
+	for _, sub := range subtitles {
+		start, err := ParseTimestamp(sub.Start)
+		if err != nil {
+			return fmt.Errorf("subtitle %d: %w", sub.Index, err)
+		}
+		end, err := ParseTimestamp(sub.End)
+		if err != nil {
+			return fmt.Errorf("subtitle %d: %w", sub.Index, err)
+		}
+
+		text := sub.Text
+		if len(sub.Translated) > 0 {
+			text = sub.Translated
+		}
+
+		if _, err := fmt.Fprintf(writer, "Dialogue: 0,%s,%s,%s,,0,0,0,,%s\n",
+			formatASSTimestamp(start), formatASSTimestamp(end), style,
+			assTagReplacer.Replace(strings.Join(text, `\N`))); err != nil {
+			return fmt.Errorf("failed to write subtitle %d: %w", sub.Index, err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write ASS output: %w", err)
+	}
+	return nil
+}
+
+// assStyleName returns "Default" if the styles section defines it,
+// otherwise the name of its first style
+func assStyleName(styles string) string {
+	first := ""
+	for _, line := range strings.Split(styles, "\n") {
+		def, ok := strings.CutPrefix(strings.TrimSpace(line), "Style:")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(def, ",")
+		name = strings.TrimSpace(name)
+		if name == "Default" {
+			return name
+		}
+		if first == "" {
+			first = name
+		}
+	}
+	if first == "" {
+		return "Default"
+	}
+	return first
+}
+
+// formatASSTimestamp formats a duration as H:MM:SS.cc
+func formatASSTimestamp(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	centis := d / (10 * time.Millisecond)
+	return fmt.Sprintf("%d:%02d:%02d.%02d",
+		centis/360000, centis/6000%60, centis/100%60, centis%100)
+}
+
+// ReadASSStyles returns the [V4+ Styles] section of an ASS file,
+// including its header, up to the next section
+func ReadASSStyles(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to open template: %w", err)
+	}
+	defer file.Close()
+
+	var section strings.Builder
+	inStyles := false
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(strings.TrimPrefix(scanner.Text(), "\ufeff"), "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			if inStyles {
+				break
+			}
+			inStyles = strings.EqualFold(trimmed, assStylesHeader)
+		}
+
+		if inStyles {
+			section.WriteString(line)
+			section.WriteString("\n")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading template: %w", err)
+	}
+
+	if section.Len() == 0 {
+		return "", fmt.Errorf("no %s section found in %s", assStylesHeader, filename)
+	}
+
+	return strings.TrimRight(section.String(), "\n") + "\n", nil
+}
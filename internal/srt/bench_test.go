@@ -0,0 +1,88 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// benchSizes are the subtitle counts of the benchmark fixtures
+var benchSizes = []int{100, 1000, 10000}
+
+var (
+	benchSubtitles = map[int][]Subtitle{}
+	benchFiles     = map[int][]byte{}
+)
+
+func TestMain(m *testing.M) {
+	for _, n := range benchSizes {
+		subs := generateSubtitles(n)
+		var buf bytes.Buffer
+		if err := NewWriter(false).WriteWriter(&buf, subs); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write %d subtitle fixture: %v\n", n, err)
+			os.Exit(1)
+		}
+		benchSubtitles[n] = subs
+		benchFiles[n] = buf.Bytes()
+	}
+	os.Exit(m.Run())
+}
+
+// generateSubtitles builds n consecutive subtitles the way the generate
+// command does, one line each with a gap between them
+func generateSubtitles(n int) []Subtitle {
+	const durationPerLine = 3 * time.Second
+	const gap = 100 * time.Millisecond
+
+	subs := make([]Subtitle, n)
+	var start time.Duration
+	for i := range subs {
+		end := start + durationPerLine
+		subs[i] = Subtitle{
+			Index: i + 1,
+			Start: FormatTimestamp(start),
+			End:   FormatTimestamp(end),
+			Text:  []string{fmt.Sprintf("Line %d of the generated script.", i+1), "A second line of text."},
+		}
+		start = end + gap
+	}
+	return subs
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, n := range benchSizes {
+		data := benchFiles[n]
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			parser := NewParser(false)
+			for i := 0; i < b.N; i++ {
+				if _, err := parser.ParseReader(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	for _, n := range benchSizes {
+		subs := benchSubtitles[n]
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.SetBytes(int64(len(benchFiles[n])))
+			b.ReportAllocs()
+			writer := NewWriter(false)
+			for i := 0; i < b.N; i++ {
+				if err := writer.WriteWriter(io.Discard, subs); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ParseChapters reads an MKVMerge simple chapter file and returns the
+// chapter start times in ascending order. Lines look like:
+//
+//	CHAPTER01=00:00:00.000
+//	CHAPTER01NAME=Intro
+func ParseChapters(filename string) ([]time.Duration, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chapters file: %w", err)
+	}
+	defer file.Close()
+
+	var chapters []time.Duration
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !strings.HasPrefix(key, "CHAPTER") || strings.HasSuffix(key, "NAME") {
+			continue
+		}
+
+		ts, err := ParseTimestamp(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chapter %s: %w", key, err)
+		}
+		chapters = append(chapters, ts)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading chapters file: %w", err)
+	}
+
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("no chapters found in %s", filename)
+	}
+
+	sort.Slice(chapters, func(i, j int) bool { return chapters[i] < chapters[j] })
+
+	return chapters, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"strings"
+	"time"
+)
+
+// CreditDuration is how long a credit block is shown
+const CreditDuration = 3 * time.Second
+
+// creditGap separates an appended credit block from the last subtitle
+const creditGap = 100 * time.Millisecond
+
+// PrependCredit returns subs with a block showing text from 00:00:00,000
+// to 00:00:03,000 added at the start, renumbered from 1. Newlines in text
+// separate its lines; the block is marked as already translated.
+func PrependCredit(subs []Subtitle, text string) []Subtitle {
+	result := make([]Subtitle, 0, len(subs)+1)
+	result = append(result, creditBlock(text, 0))
+	result = append(result, subs...)
+	return Reindex(result)
+}
+
+// AppendCredit returns subs with a block showing text for three seconds
+// from 100ms after the last subtitle ends added at the end. Newlines in
+// text separate its lines; the block is marked as already translated.
+func AppendCredit(subs []Subtitle, text string) []Subtitle {
+	var start time.Duration
+	if len(subs) > 0 {
+		if end, err := ParseTimestamp(subs[len(subs)-1].End); err == nil {
+			start = end + creditGap
+		}
+	}
+
+	credit := creditBlock(text, start)
+	if len(subs) > 0 {
+		credit.Index = subs[len(subs)-1].Index + 1
+	}
+	result := make([]Subtitle, 0, len(subs)+1)
+	result = append(result, subs...)
+	return append(result, credit)
+}
+
+// creditBlock returns a subtitle showing text from start for CreditDuration
+func creditBlock(text string, start time.Duration) Subtitle {
+	lines := strings.Split(text, "\n")
+	return Subtitle{
+		Index:      1,
+		Start:      FormatTimestamp(start),
+		End:        FormatTimestamp(start + CreditDuration),
+		Text:       lines,
+		Translated: lines,
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Encoding is the character encoding used when writing subtitle files
+type Encoding string
+
+const (
+	EncodingUTF8    Encoding = "utf8"
+	EncodingUTF8BOM Encoding = "utf8bom"
+	EncodingLatin1  Encoding = "latin1"
+	EncodingCP1252  Encoding = "cp1252"
+)
+
+// replacementByte is written for characters the target encoding cannot represent
+const replacementByte = '?'
+
+// ParseEncoding validates an output encoding name
+func ParseEncoding(name string) (Encoding, error) {
+	switch Encoding(name) {
+	case "":
+		return EncodingUTF8, nil
+	case EncodingUTF8, EncodingUTF8BOM, EncodingLatin1, EncodingCP1252:
+		return Encoding(name), nil
+	default:
+		return "", fmt.Errorf("unknown encoding: %s (expected utf8, utf8bom, latin1 or cp1252)", name)
+	}
+}
+
+// charmap returns the single-byte character map of the encoding, or nil
+// for the UTF-8 encodings
+func (e Encoding) charmap() *charmap.Charmap {
+	switch e {
+	case EncodingLatin1:
+		return charmap.ISO8859_1
+	case EncodingCP1252:
+		return charmap.Windows1252
+	default:
+		return nil
+	}
+}
+
+// singleByte reports whether the encoding maps each character to one byte
+func (e Encoding) singleByte() bool {
+	return e.charmap() != nil
+}
+
+// encodeRune returns the byte for r in a single-byte encoding
+func (e Encoding) encodeRune(r rune) (byte, bool) {
+	return e.charmap().EncodeRune(r)
+}
+
+// CanEncode reports whether r can be represented in the encoding
+func (e Encoding) CanEncode(r rune) bool {
+	if !e.singleByte() {
+		return true
+	}
+	_, ok := e.encodeRune(r)
+	return ok
+}
+
+// EncodingIssue describes a character that cannot be written in an encoding
+type EncodingIssue struct {
+	Index int
+	Char  rune
+}
+
+// FindUnencodable returns the characters in the subtitles' output text
+// that the encoding cannot represent
+func FindUnencodable(subs []Subtitle, enc Encoding) []EncodingIssue {
+	if !enc.singleByte() {
+		return nil
+	}
+
+	var issues []EncodingIssue
+	for _, sub := range subs {
+		text := sub.Text
+		if len(sub.Translated) > 0 {
+			text = sub.Translated
+		}
+		for _, line := range text {
+			for _, r := range line {
+				if !enc.CanEncode(r) {
+					issues = append(issues, EncodingIssue{Index: sub.Index, Char: r})
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// encodingWriter transcodes UTF-8 written to it into a single-byte encoding.
+// Each Write must contain complete UTF-8 sequences.
+type encodingWriter struct {
+	w   io.Writer
+	enc Encoding
+	buf []byte
+}
+
+// newEncodingWriter wraps w so that output is written in enc
+func newEncodingWriter(w io.Writer, enc Encoding) io.Writer {
+	if !enc.singleByte() {
+		return w
+	}
+	return &encodingWriter{w: w, enc: enc}
+}
+
+// Write implements io.Writer
+func (ew *encodingWriter) Write(p []byte) (int, error) {
+	ew.buf = ew.buf[:0]
+	for i := 0; i < len(p); {
+		r, size := utf8.DecodeRune(p[i:])
+		b, ok := ew.enc.encodeRune(r)
+		if !ok {
+			b = replacementByte
+		}
+		ew.buf = append(ew.buf, b)
+		i += size
+	}
+
+	if _, err := ew.w.Write(ew.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FilterShortSubtitles removes subtitles displayed for less than min and
+// reindexes the result. Subtitles with unparseable timestamps are kept.
+func FilterShortSubtitles(subs []Subtitle, min time.Duration) []Subtitle {
+	return Reindex(RemoveShort(subs, min))
+}
+
+// RemoveShort removes subtitles displayed for less than min without
+// reindexing. Subtitles with unparseable timestamps are kept.
+func RemoveShort(subs []Subtitle, min time.Duration) []Subtitle {
+	result := make([]Subtitle, 0, len(subs))
+	for _, sub := range subs {
+		if d, err := sub.Duration(); err == nil && d < min {
+			continue
+		}
+		result = append(result, sub)
+	}
+	return result
+}
+
+// FilterEmptySubtitles removes subtitles whose text is empty or only
+// whitespace and reindexes the result
+func FilterEmptySubtitles(subs []Subtitle) []Subtitle {
+	return Reindex(RemoveEmpty(subs))
+}
+
+// RemoveEmpty removes subtitles without any non-blank text line. The
+// result is not reindexed.
+func RemoveEmpty(subs []Subtitle) []Subtitle {
+	result := make([]Subtitle, 0, len(subs))
+	for _, sub := range subs {
+		for _, line := range sub.Text {
+			if strings.TrimSpace(line) != "" {
+				result = append(result, sub)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Reindex renumbers subtitles sequentially starting at 1
+func Reindex(subs []Subtitle) []Subtitle {
+	for i := range subs {
+		subs[i].Index = i + 1
+	}
+	return subs
+}
+
+// Truncate keeps subtitles starting at or before at, or only those starting
+// after it when keepAfter is set, and reindexes the result
+func Truncate(subs []Subtitle, at time.Duration, keepAfter bool) ([]Subtitle, error) {
+	result := make([]Subtitle, 0, len(subs))
+	for _, sub := range subs {
+		start, err := ParseTimestamp(sub.Start)
+		if err != nil {
+			return nil, fmt.Errorf("subtitle %d: %w", sub.Index, err)
+		}
+		if (start > at) == keepAfter {
+			result = append(result, sub)
+		}
+	}
+	return Reindex(result), nil
+}
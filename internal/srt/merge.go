@@ -0,0 +1,48 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Merge combines two versions of the same subtitle file by index, e.g. a
+// partial human translation and a machine translation. A subtitle in both
+// is taken from a unless it has no translation; a subtitle in only one is
+// included as is. The result is sorted by the original index and reindexed.
+func Merge(a, b []Subtitle) ([]Subtitle, error) {
+	byIndex := make(map[int]Subtitle, len(a)+len(b))
+	for _, sub := range b {
+		if _, ok := byIndex[sub.Index]; ok {
+			return nil, fmt.Errorf("duplicate subtitle index %d in second file", sub.Index)
+		}
+		byIndex[sub.Index] = sub
+	}
+
+	seen := make(map[int]bool, len(a))
+	for _, sub := range a {
+		if seen[sub.Index] {
+			return nil, fmt.Errorf("duplicate subtitle index %d in first file", sub.Index)
+		}
+		seen[sub.Index] = true
+
+		if _, ok := byIndex[sub.Index]; ok && len(sub.Translated) == 0 {
+			continue
+		}
+		byIndex[sub.Index] = sub
+	}
+
+	indexes := make([]int, 0, len(byIndex))
+	for index := range byIndex {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	merged := make([]Subtitle, len(indexes))
+	for i, index := range indexes {
+		merged[i] = byIndex[index]
+	}
+	return Reindex(merged), nil
+}
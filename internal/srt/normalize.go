@@ -0,0 +1,44 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import "strings"
+
+// TrimLines removes leading and trailing whitespace from every text line
+func TrimLines(subs []Subtitle) []Subtitle {
+	result := make([]Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+		lines := make([]string, len(sub.Text))
+		for j, line := range sub.Text {
+			lines[j] = strings.TrimSpace(line)
+		}
+		result[i].Text = lines
+	}
+	return result
+}
+
+// FixOverlaps ends each subtitle no later than the start of the next one
+// and returns the number of subtitles changed. Pairs with unparseable
+// timestamps, or where the next subtitle does not start later, are left
+// unchanged; sort such files with SortByStart first.
+func FixOverlaps(subs []Subtitle) ([]Subtitle, int) {
+	result := make([]Subtitle, len(subs))
+	copy(result, subs)
+
+	fixed := 0
+	for i := 0; i+1 < len(result); i++ {
+		start, startErr := ParseTimestamp(result[i].Start)
+		end, endErr := ParseTimestamp(result[i].End)
+		next, nextErr := ParseTimestamp(result[i+1].Start)
+		if startErr != nil || endErr != nil || nextErr != nil {
+			continue
+		}
+		if end > next && next > start {
+			result[i].End = FormatTimestamp(next)
+			fixed++
+		}
+	}
+	return result, fixed
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SortByStart returns a copy of subs sorted by start time along with the
+// number of blocks that changed position. The result is not reindexed.
+func SortByStart(subs []Subtitle) ([]Subtitle, int, error) {
+	type entry struct {
+		sub      Subtitle
+		start    time.Duration
+		position int
+	}
+
+	entries := make([]entry, len(subs))
+	for i, sub := range subs {
+		start, err := ParseTimestamp(sub.Start)
+		if err != nil {
+			return nil, 0, fmt.Errorf("subtitle %d: %w", sub.Index, err)
+		}
+		entries[i] = entry{sub: sub, start: start, position: i}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].start < entries[j].start
+	})
+
+	sorted := make([]Subtitle, len(entries))
+	moved := 0
+	for i, e := range entries {
+		sorted[i] = e.sub
+		if e.position != i {
+			moved++
+		}
+	}
+
+	return sorted, moved, nil
+}
+
+// FindOverlaps returns the indexes of subtitles that start before the
+// previous subtitle ends. subs must be sorted by start time.
+func FindOverlaps(subs []Subtitle) []int {
+	var overlaps []int
+	var prevEnd time.Duration
+	for i, sub := range subs {
+		start, startErr := ParseTimestamp(sub.Start)
+		end, endErr := ParseTimestamp(sub.End)
+		if startErr != nil || endErr != nil {
+			continue
+		}
+		if i > 0 && start < prevEnd {
+			overlaps = append(overlaps, sub.Index)
+		}
+		if end > prevEnd {
+			prevEnd = end
+		}
+	}
+	return overlaps
+}
@@ -5,10 +5,12 @@ package srt
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
 	"strings"
+	"time"
 )
 
 // Subtitle represents a single subtitle block in an SRT file
@@ -18,11 +20,43 @@ type Subtitle struct {
 	End        string
 	Text       []string
 	Translated []string
+	// Metadata carries per-subtitle state from pre-processing steps
+	// to their post-processing counterparts
+	Metadata map[string]interface{}
 }
 
-// Parser handles SRT file parsing and writing
+// Duration returns how long the subtitle is displayed
+func (s Subtitle) Duration() (time.Duration, error) {
+	start, err := ParseTimestamp(s.Start)
+	if err != nil {
+		return 0, err
+	}
+	end, err := ParseTimestamp(s.End)
+	if err != nil {
+		return 0, err
+	}
+	return end - start, nil
+}
+
+// IsValid reports whether the subtitle has text and a positive duration
+func (s Subtitle) IsValid() bool {
+	d, err := s.Duration()
+	return err == nil && d > 0 && len(s.Text) > 0
+}
+
+// SourceAnnotationPrefix marks original text lines written by Writer.AnnotateSource
+const SourceAnnotationPrefix = "# Original: "
+
+// Parser handles SRT file parsing
 type Parser struct {
 	Verbose bool
+	// Lenient recovers from malformed blocks: stray lines between blocks
+	// are skipped and a timestamp line after a blank line starts a new
+	// subtitle even if its index line is missing or invalid
+	Lenient bool
+	// KeepCarriageReturns keeps carriage returns inside lines instead of
+	// removing them; those ending a line are always removed
+	KeepCarriageReturns bool
 }
 
 // NewParser creates a new SRT parser
@@ -40,55 +74,146 @@ func (p *Parser) Parse(filename string) ([]Subtitle, error) {
 	}
 	defer file.Close()
 
+	subtitles, err := p.ParseReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Verbose {
+		fmt.Fprintf(os.Stderr, "Parsed %d subtitles from %s\n", len(subtitles), filename)
+	}
+
+	return subtitles, nil
+}
+
+// ParseString parses SRT content held in memory
+func (p *Parser) ParseString(content string) ([]Subtitle, error) {
+	return p.ParseReader(strings.NewReader(content))
+}
+
+// ParseReader reads SRT content from r and returns a slice of Subtitle structs
+func (p *Parser) ParseReader(r io.Reader) ([]Subtitle, error) {
 	var subtitles []Subtitle
-	var current *Subtitle
+	var current Subtitle
+	hasCurrent := false
 
-	scanner := bufio.NewScanner(file)
+	// a number inside a text block is only treated as the next index
+	// if it is followed by a timestamp line, otherwise it is text
+	var pendingIndex []byte
+	firstLine := true
+
+	// state used by lenient mode to resync after malformed lines
+	afterBlank := true
+	resyncing := false
+	lineNo := 0
+
+	// work on the scanner's byte slices and only allocate strings
+	// for the parts we keep, which dominates parsing time on large files
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
-		line := scanner.Text()
-		line = strings.TrimSpace(line)
+		line := scanner.Bytes()
+		if firstLine {
+			line = bytes.TrimPrefix(line, utf8BOM)
+			firstLine = false
+		}
+		// Windows line endings leave a trailing \r, and stray carriage
+		// returns can appear anywhere, including around the separator
+		if p.KeepCarriageReturns {
+			line = bytes.TrimSuffix(line, carriageReturn)
+		} else if bytes.IndexByte(line, '\r') >= 0 {
+			line = bytes.ReplaceAll(line, carriageReturn, nil)
+		}
+		line = bytes.TrimSpace(line)
+		lineNo++
+
+		if pendingIndex != nil {
+			if isTimestampLine(line) {
+				index, _ := parseIndex(pendingIndex)
+				subtitles = append(subtitles, current)
+				current = Subtitle{Index: index}
+			} else {
+				current.Text = append(current.Text, string(pendingIndex))
+			}
+			pendingIndex = nil
+		}
 
 		// Skip empty lines
-		if line == "" {
+		if len(line) == 0 {
+			afterBlank = true
 			continue
 		}
+		blockStart := afterBlank
+		afterBlank = false
 
 		// Check if this is a new subtitle index
-		if index, err := strconv.Atoi(line); err == nil {
-			// Save the previous subtitle if it exists
-			if current != nil && current.Start != "" {
-				subtitles = append(subtitles, *current)
+		if index, ok := parseIndex(line); ok {
+			// Defer the decision until we see whether a timestamp follows
+			if hasCurrent && current.Start != "" && !resyncing {
+				pendingIndex = append(pendingIndex[:0], line...)
+				continue
+			}
+			// Save a subtitle we were resyncing after, then start a new
+			// subtitle, discarding an index without timestamp
+			if hasCurrent && current.Start != "" {
+				subtitles = append(subtitles, current)
 			}
-			// Start a new subtitle
-			current = &Subtitle{Index: index}
+			current = Subtitle{Index: index}
+			hasCurrent = true
+			resyncing = false
 			continue
 		}
 
+		if p.Lenient {
+			// A timestamp at the start of a block means its index is missing
+			if isTimestampLine(line) && (blockStart || resyncing || !hasCurrent) && (!hasCurrent || current.Start != "") {
+				index := 1
+				if hasCurrent {
+					subtitles = append(subtitles, current)
+					index = current.Index + 1
+				}
+				current = Subtitle{Index: index}
+				hasCurrent = true
+				resyncing = false
+				p.debugf("line %d: missing subtitle index, using %d", lineNo, current.Index)
+			} else if resyncing || (blockStart && hasCurrent && current.Start != "") {
+				p.debugf("line %d: skipping malformed line %q", lineNo, line)
+				resyncing = true
+				continue
+			} else if !hasCurrent || (current.Start == "" && !isTimestampLine(line)) {
+				p.debugf("line %d: expected subtitle index or timestamp, got %q", lineNo, line)
+				continue
+			}
+		}
+
 		// If we don't have a current subtitle, skip this line
-		if current == nil {
+		if !hasCurrent {
 			continue
 		}
 
 		// Try to parse as timestamp
 		if current.Start == "" {
-			if strings.Contains(line, " --> ") {
-				times := strings.Split(line, " --> ")
-				if len(times) == 2 {
-					current.Start = times[0]
-					current.End = times[1]
-					continue
-				}
+			if isTimestampLine(line) {
+				// a single allocation shared by both timestamps
+				ts := string(line)
+				start, end, _ := strings.Cut(ts, timestampSeparator)
+				current.Start = strings.TrimSpace(start)
+				current.End = strings.TrimSpace(end)
+				current.Text = make([]string, 0, 2)
 			}
 			continue
 		}
 
 		// If we get here, this must be subtitle text
-		current.Text = append(current.Text, line)
+		current.Text = append(current.Text, string(line))
+	}
+
+	if pendingIndex != nil {
+		current.Text = append(current.Text, string(pendingIndex))
 	}
 
 	// Don't forget the last subtitle
-	if current != nil && current.Start != "" {
-		subtitles = append(subtitles, *current)
+	if hasCurrent && current.Start != "" {
+		subtitles = append(subtitles, current)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -100,58 +225,64 @@ func (p *Parser) Parse(filename string) ([]Subtitle, error) {
 	}
 
 	if p.Verbose {
-		fmt.Printf("Parsed %d subtitles from %s\n", len(subtitles), filename)
+		for _, sub := range subtitles {
+			if !sub.IsValid() {
+				p.debugf("subtitle %d has no text or does not end after it starts (%s --> %s)", sub.Index, sub.Start, sub.End)
+			}
+		}
 	}
 
 	return subtitles, nil
 }
 
-// Write saves the translated subtitles to a new SRT file
-func (p *Parser) Write(filename string, subtitles []Subtitle) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
+// timestampSeparator separates start and end times; surrounding
+// whitespace is optional when parsing
+const timestampSeparator = "-->"
 
-	writer := bufio.NewWriter(file)
-	for i, sub := range subtitles {
-		// Write subtitle index
-		if _, err := fmt.Fprintf(writer, "%d\n", sub.Index); err != nil {
-			return fmt.Errorf("failed to write index: %w", err)
-		}
+var (
+	utf8BOM        = []byte("\ufeff")
+	carriageReturn = []byte("\r")
+)
 
-		// Write timestamps
-		if _, err := fmt.Fprintf(writer, "%s --> %s\n", sub.Start, sub.End); err != nil {
-			return fmt.Errorf("failed to write timestamps: %w", err)
-		}
+// debugf prints a parser diagnostic in verbose mode. Diagnostics go to
+// stderr so they never mix with subtitles written to stdout.
+func (p *Parser) debugf(format string, args ...interface{}) {
+	if p.Verbose {
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+	}
+}
 
-		// Write translated text or original if translation is empty
-		text := []string{sub.Text}
-		if len(sub.Translated) > 0 {
-			text = append(text, sub.Translated)
-		}
-		for _, line := range text {
-			if _, err := fmt.Fprintf(writer, "%s\n", line); err != nil {
-				return fmt.Errorf("failed to write text: %w", err)
-			}
-		}
+// isTimestampLine reports whether line looks like "start --> end"
+func isTimestampLine(line []byte) bool {
+	return bytes.Count(line, []byte(timestampSeparator)) == 1
+}
 
-		// Add blank line between subtitles (except for last one)
-		if i < len(subtitles)-1 {
-			if _, err := fmt.Fprintf(writer, "\n"); err != nil {
-				return fmt.Errorf("failed to write separator: %w", err)
-			}
-		}
+// parseIndex parses a subtitle index without allocating
+func parseIndex(line []byte) (int, bool) {
+	if len(line) == 0 || len(line) > 9 {
+		return 0, false
 	}
-
-	if err := writer.Flush(); err != nil {
-		return fmt.Errorf("failed to flush writer: %w", err)
+	n := 0
+	for _, c := range line {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
 	}
+	return n, true
+}
 
-	if p.Verbose {
-		fmt.Printf("Wrote %d subtitles to %s\n", len(subtitles), filename)
+// StripSourceAnnotations removes "# Original:" annotation lines from subtitle text
+func StripSourceAnnotations(subtitles []Subtitle) []Subtitle {
+	result := make([]Subtitle, len(subtitles))
+	for i, sub := range subtitles {
+		result[i] = sub
+		result[i].Text = nil
+		for _, line := range sub.Text {
+			if !strings.HasPrefix(line, SourceAnnotationPrefix) {
+				result[i].Text = append(result[i].Text, line)
+			}
+		}
 	}
-
-	return nil
+	return result
 }
@@ -0,0 +1,30 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzParseReader(f *testing.F) {
+	for _, seed := range []string{
+		"1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+		"\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\nthere\r\n",
+		"1\n00:00:01,000-->00:00:02,000\n42\n\n2\n00:00:03,000 --> 00:00:04,000\n",
+		"garbage\n\n00:00:01,000 --> 00:00:02,000\ntext\n",
+		"",
+	} {
+		f.Add(seed, false)
+		f.Add(seed, true)
+	}
+
+	f.Fuzz(func(t *testing.T, input string, lenient bool) {
+		parser := &Parser{Lenient: lenient}
+		subtitles, err := parser.ParseReader(strings.NewReader(input))
+		if err == nil && len(subtitles) == 0 {
+			t.Errorf("ParseReader returned no subtitles and no error")
+		}
+	})
+}
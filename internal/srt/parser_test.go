@@ -0,0 +1,146 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Subtitle
+		wantErr bool
+	}{
+		{
+			name:    "well-formed",
+			content: "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nWorld\n\n",
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello"}},
+				{Index: 2, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"World"}},
+			},
+		},
+		{
+			name:    "byte order mark",
+			content: "\ufeff1\n00:00:01,000 --> 00:00:02,000\nHello\n",
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello"}},
+			},
+		},
+		{
+			name:    "windows line endings",
+			content: "1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\nthere\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nWorld\r\n",
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello", "there"}},
+				{Index: 2, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"World"}},
+			},
+		},
+		{
+			name:    "missing final blank line",
+			content: "1\n00:00:01,000 --> 00:00:02,000\nHello",
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello"}},
+			},
+		},
+		{
+			name:    "three text lines",
+			content: "1\n00:00:01,000 --> 00:00:02,000\nOne\nTwo\nThree\n",
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"One", "Two", "Three"}},
+			},
+		},
+		{
+			name:    "empty file",
+			content: "",
+			wantErr: true,
+		},
+		{
+			name:    "only whitespace",
+			content: " \n\t\n\r\n  \n",
+			wantErr: true,
+		},
+		{
+			name:    "non-sequential indexes",
+			content: "5\n00:00:01,000 --> 00:00:02,000\nHello\n\n3\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			want: []Subtitle{
+				{Index: 5, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello"}},
+				{Index: 3, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"World"}},
+			},
+		},
+		{
+			name:    "timestamps with trailing spaces",
+			content: "1\n00:00:01,000 --> 00:00:02,000   \nHello\n",
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello"}},
+			},
+		},
+		{
+			name:    "text that looks like an index",
+			content: "1\n00:00:01,000 --> 00:00:02,000\nThe answer is\n42\n\n2\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"The answer is", "42"}},
+				{Index: 2, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"World"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(false).ParseString(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseString() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseString() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStringCarriageReturns(t *testing.T) {
+	content := "1\r\n00:00:01,000\r --> \r00:00:02,000\r\nHello\r\nthe\rre\r\n\r\n2\r\n00:00:03,000-->00:00:04,000\r\nWorld\r\n"
+
+	tests := []struct {
+		name   string
+		parser *Parser
+		want   []Subtitle
+	}{
+		{
+			name:   "removed",
+			parser: &Parser{},
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello", "there"}},
+				{Index: 2, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"World"}},
+			},
+		},
+		{
+			name:   "kept inside lines",
+			parser: &Parser{KeepCarriageReturns: true},
+			want: []Subtitle{
+				{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello", "the\rre"}},
+				{Index: 2, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"World"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parser.ParseString(content)
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseString() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
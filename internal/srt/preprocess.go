@@ -0,0 +1,301 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// musicNotesKey is the Metadata key holding the notes removed by StripMusicNotes
+const musicNotesKey = "music_notes"
+
+// musicNoteChars are the characters removed by StripMusicNotes
+const musicNoteChars = "♪♫♬♩"
+
+// musicNote records a note removed from a line and where it was,
+// as a fraction of the stripped line's length
+type musicNote struct {
+	Line     int
+	Note     rune
+	Position float64
+}
+
+// strippedMusicNotes is stored in Subtitle.Metadata by StripMusicNotes
+type strippedMusicNotes struct {
+	Original []string
+	Notes    []musicNote
+}
+
+// StripMusicNotes removes music note characters from subtitle text so they
+// do not confuse translation models. The removed notes are recorded in
+// Metadata for RestoreMusicNotes. Subtitles consisting only of notes are
+// left unchanged.
+func StripMusicNotes(subs []Subtitle) []Subtitle {
+	result := make([]Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+
+		var notes []musicNote
+		lines := make([]string, len(sub.Text))
+		empty := true
+		for j, line := range sub.Text {
+			lines[j], notes = stripLineMusicNotes(line, j, notes)
+			if lines[j] != "" {
+				empty = false
+			}
+		}
+		if len(notes) == 0 || empty {
+			continue
+		}
+
+		result[i].Text = lines
+		result[i].Metadata = withMetadata(sub.Metadata, musicNotesKey, strippedMusicNotes{
+			Original: sub.Text,
+			Notes:    notes,
+		})
+	}
+	return result
+}
+
+// stripLineMusicNotes removes music notes from line, appending them to notes
+func stripLineMusicNotes(line string, lineIndex int, notes []musicNote) (string, []musicNote) {
+	if !strings.ContainsAny(line, musicNoteChars) {
+		return line, notes
+	}
+
+	// positions are recorded against the text without notes and
+	// surrounding whitespace, which is what the model will see
+	var found []rune
+	var offsets []int
+	var b strings.Builder
+	for _, r := range line {
+		if strings.ContainsRune(musicNoteChars, r) {
+			found = append(found, r)
+			offsets = append(offsets, len([]rune(strings.TrimLeft(b.String(), " "))))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	stripped := strings.Join(strings.Fields(b.String()), " ")
+	length := len([]rune(stripped))
+	for k, r := range found {
+		position := 1.0
+		if length > 0 {
+			position = math.Min(float64(offsets[k])/float64(length), 1)
+		}
+		notes = append(notes, musicNote{Line: lineIndex, Note: r, Position: position})
+	}
+	return stripped, notes
+}
+
+// RestoreMusicNotes reinserts the notes removed by StripMusicNotes into the
+// translated text at the same relative positions, and restores the original
+// text. Notes for lines the translation does not have go on its last line.
+func RestoreMusicNotes(subs []Subtitle) []Subtitle {
+	result := make([]Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+
+		stripped, ok := sub.Metadata[musicNotesKey].(strippedMusicNotes)
+		if !ok {
+			continue
+		}
+
+		lines := sub.Translated
+		if len(lines) == 0 {
+			lines = sub.Text
+		}
+		lines = append([]string(nil), lines...)
+
+		// insert from the end of each line so earlier positions stay valid
+		for k := len(stripped.Notes) - 1; k >= 0; k-- {
+			note := stripped.Notes[k]
+			line := note.Line
+			if line >= len(lines) {
+				line = len(lines) - 1
+			}
+			if line < 0 {
+				continue
+			}
+			lines[line] = insertMusicNote(lines[line], note)
+		}
+
+		if len(sub.Translated) > 0 {
+			result[i].Translated = lines
+			result[i].Text = stripped.Original
+		} else {
+			result[i].Text = lines
+		}
+		result[i].Metadata = withoutMetadata(sub.Metadata, musicNotesKey)
+	}
+	return result
+}
+
+// insertMusicNote inserts note into line at its relative position,
+// moved to the nearest word boundary
+func insertMusicNote(line string, note musicNote) string {
+	runes := []rune(line)
+	pos := int(math.Round(note.Position * float64(len(runes))))
+
+	switch {
+	case pos <= 0:
+		return strings.TrimSpace(string(note.Note) + " " + line)
+	case pos >= len(runes):
+		return strings.TrimSpace(line + " " + string(note.Note))
+	}
+
+	// find the nearest space so words are not split
+	best := -1
+	for d := 0; d < len(runes); d++ {
+		if pos-d >= 0 && runes[pos-d] == ' ' {
+			best = pos - d
+			break
+		}
+		if pos+d < len(runes) && runes[pos+d] == ' ' {
+			best = pos + d
+			break
+		}
+	}
+	if best < 0 {
+		if note.Position < 0.5 {
+			return string(note.Note) + " " + line
+		}
+		return line + " " + string(note.Note)
+	}
+
+	return string(runes[:best]) + " " + string(note.Note) + string(runes[best:])
+}
+
+// CompressWhitespace collapses runs of spaces and tabs in each text line
+// into a single space and trims the lines, so the model sees clean text
+func CompressWhitespace(subs []Subtitle) []Subtitle {
+	result := make([]Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+		lines := make([]string, len(sub.Text))
+		for j, line := range sub.Text {
+			lines[j] = strings.Join(strings.Fields(line), " ")
+		}
+		result[i].Text = lines
+	}
+	return result
+}
+
+// mergedLinesKey is the Metadata key holding the lines split by SplitMergedLines
+const mergedLinesKey = "merged_lines"
+
+// splitLines is stored in Subtitle.Metadata by SplitMergedLines
+type splitLines struct {
+	Original []string
+	// Counts is the number of lines each original line was split into
+	Counts []int
+}
+
+// SplitMergedLines splits lines that a converter joined with "|", as in
+// "First line|Second line", into separate lines. Only a "|" with no space
+// on either side is treated as a line break. The original lines are
+// recorded in Metadata for JoinMergedLines.
+func SplitMergedLines(subs []Subtitle) []Subtitle {
+	result := make([]Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+
+		var lines []string
+		counts := make([]int, len(sub.Text))
+		split := false
+		for j, line := range sub.Text {
+			parts := splitMergedLine(line)
+			lines = append(lines, parts...)
+			counts[j] = len(parts)
+			split = split || len(parts) > 1
+		}
+		if !split {
+			continue
+		}
+
+		result[i].Text = lines
+		result[i].Metadata = withMetadata(sub.Metadata, mergedLinesKey, splitLines{
+			Original: sub.Text,
+			Counts:   counts,
+		})
+	}
+	return result
+}
+
+// splitMergedLine splits line at each "|" between two non-space characters
+func splitMergedLine(line string) []string {
+	runes := []rune(line)
+	var parts []string
+	start := 0
+	for k := 1; k < len(runes)-1; k++ {
+		if runes[k] == '|' && !unicode.IsSpace(runes[k-1]) && !unicode.IsSpace(runes[k+1]) && runes[k-1] != '|' && runes[k+1] != '|' {
+			parts = append(parts, string(runes[start:k]))
+			start = k + 1
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
+
+// JoinMergedLines undoes SplitMergedLines: the original text is restored
+// and, when the translation has as many lines as were split, translated
+// lines are joined with "|" the same way. Translations with a different
+// number of lines are kept as they are.
+func JoinMergedLines(subs []Subtitle) []Subtitle {
+	result := make([]Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+
+		split, ok := sub.Metadata[mergedLinesKey].(splitLines)
+		if !ok {
+			continue
+		}
+
+		total := 0
+		for _, n := range split.Counts {
+			total += n
+		}
+		if len(sub.Translated) == total {
+			joined := make([]string, 0, len(split.Counts))
+			pos := 0
+			for _, n := range split.Counts {
+				joined = append(joined, strings.Join(sub.Translated[pos:pos+n], "|"))
+				pos += n
+			}
+			result[i].Translated = joined
+		}
+		result[i].Text = split.Original
+		result[i].Metadata = withoutMetadata(sub.Metadata, mergedLinesKey)
+	}
+	return result
+}
+
+// withMetadata returns a copy of metadata with key set to value
+func withMetadata(metadata map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(metadata)+1)
+	for k, v := range metadata {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+// withoutMetadata returns a copy of metadata without key, or nil if empty
+func withoutMetadata(metadata map[string]interface{}, key string) map[string]interface{} {
+	if _, ok := metadata[key]; !ok {
+		return metadata
+	}
+	if len(metadata) == 1 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		if k != key {
+			result[k] = v
+		}
+	}
+	return result
+}
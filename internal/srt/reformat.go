@@ -0,0 +1,141 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// EllipsisStyle selects how Reformat writes ellipses
+type EllipsisStyle string
+
+const (
+	// EllipsisKeep leaves ellipses as they are
+	EllipsisKeep EllipsisStyle = "keep"
+	// EllipsisUnicode writes the single character …
+	EllipsisUnicode EllipsisStyle = "unicode"
+	// EllipsisASCII writes three dots
+	EllipsisASCII EllipsisStyle = "ascii"
+)
+
+// ParseEllipsisStyle validates an ellipsis style name
+func ParseEllipsisStyle(name string) (EllipsisStyle, error) {
+	switch EllipsisStyle(name) {
+	case EllipsisKeep, EllipsisUnicode, EllipsisASCII:
+		return EllipsisStyle(name), nil
+	default:
+		return "", fmt.Errorf("unknown ellipsis style: %s (expected keep, unicode or ascii)", name)
+	}
+}
+
+// QuoteStyle selects how Reformat writes quote marks
+type QuoteStyle string
+
+const (
+	// QuotesKeep leaves quote marks as they are
+	QuotesKeep QuoteStyle = "keep"
+	// QuotesStraight writes " and '
+	QuotesStraight QuoteStyle = "straight"
+	// QuotesCurly writes “ ” and ‘ ’
+	QuotesCurly QuoteStyle = "curly"
+)
+
+// ParseQuoteStyle validates a quote style name
+func ParseQuoteStyle(name string) (QuoteStyle, error) {
+	switch QuoteStyle(name) {
+	case QuotesKeep, QuotesStraight, QuotesCurly:
+		return QuoteStyle(name), nil
+	default:
+		return "", fmt.Errorf("unknown quote style: %s (expected keep, straight or curly)", name)
+	}
+}
+
+// ReformatOptions configures Reformat
+type ReformatOptions struct {
+	Ellipsis EllipsisStyle
+	Quotes   QuoteStyle
+}
+
+// spaceBeforePunctuation matches spaces before punctuation that should
+// follow the preceding word directly, as in "Hello .". It leaves "!", "?",
+// ";" and ":" alone, which French typography puts after a space.
+var spaceBeforePunctuation = regexp.MustCompile(`(\S) +([.,…])`)
+
+// straightQuotes maps curly quote marks to their straight forms
+var straightQuotes = strings.NewReplacer("“", `"`, "”", `"`, "„", `"`, "‘", "'", "’", "'", "‚", "'")
+
+// Reformat normalizes the whitespace and punctuation of subtitle text:
+// runs of spaces are collapsed, lines are trimmed, spaces before periods,
+// commas and ellipses are removed and ellipses and quote marks are rewritten in
+// the selected styles.
+func Reformat(subs []Subtitle, opts ReformatOptions) []Subtitle {
+	result := make([]Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+		result[i].Text = reformatLines(sub.Text, opts)
+		if len(sub.Translated) > 0 {
+			result[i].Translated = reformatLines(sub.Translated, opts)
+		}
+	}
+	return result
+}
+
+// reformatLines returns the reformatted lines
+func reformatLines(lines []string, opts ReformatOptions) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = reformatLine(line, opts)
+	}
+	return result
+}
+
+// reformatLine normalizes the whitespace and punctuation of a single line
+func reformatLine(line string, opts ReformatOptions) string {
+	line = strings.Join(strings.Fields(line), " ")
+
+	switch opts.Ellipsis {
+	case EllipsisUnicode:
+		line = strings.ReplaceAll(line, "...", "…")
+	case EllipsisASCII:
+		line = strings.ReplaceAll(line, "…", "...")
+	}
+
+	line = spaceBeforePunctuation.ReplaceAllString(line, "$1$2")
+
+	switch opts.Quotes {
+	case QuotesStraight:
+		line = straightQuotes.Replace(line)
+	case QuotesCurly:
+		line = curlyQuotes(line)
+	}
+	return line
+}
+
+// curlyQuotes replaces straight quote marks with curly ones. A quote mark
+// at the start of the line or after a space or opening bracket opens a
+// quotation; any other closes one, so apostrophes become ’.
+func curlyQuotes(line string) string {
+	var b strings.Builder
+	prev := ' '
+	for _, r := range line {
+		opening := unicode.IsSpace(prev) || strings.ContainsRune("([{-—", prev)
+		switch {
+		case r == '"' && opening:
+			b.WriteRune('“')
+		case r == '"':
+			b.WriteRune('”')
+		case r == '\'' && opening:
+			b.WriteRune('‘')
+		case r == '\'':
+			b.WriteRune('’')
+		default:
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import "testing"
+
+func TestReformatSpaceBeforePunctuation(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{name: "period", line: "Hello .", want: "Hello."},
+		{name: "comma", line: "Yes , sir", want: "Yes, sir"},
+		{name: "ellipsis", line: "Wait …", want: "Wait…"},
+		{name: "ascii ellipsis", line: "Wait ...", want: "Wait..."},
+		{name: "french question", line: "Tu viens ?", want: "Tu viens ?"},
+		{name: "french exclamation", line: "Allez !", want: "Allez !"},
+		{name: "french colon and semicolon", line: "Note : oui ; non", want: "Note : oui ; non"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := []Subtitle{{Index: 1, Text: []string{tt.line}}}
+			got := Reformat(subs, ReformatOptions{Ellipsis: EllipsisKeep})
+			if got[0].Text[0] != tt.want {
+				t.Errorf("Reformat(%q) = %q, want %q", tt.line, got[0].Text[0], tt.want)
+			}
+		})
+	}
+}
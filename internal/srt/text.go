@@ -0,0 +1,62 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// formattingTag matches SRT formatting tags such as <i>, </b>, <font ...>
+// and ASS override tags such as {\an8}
+var formattingTag = regexp.MustCompile(`</?(?:[ibu]|font)(?:\s[^>]*)?>|\{\\[^}]*\}`)
+
+// TextWriter writes subtitle text as plain text, one paragraph per subtitle
+type TextWriter struct {
+	// IncludeTimestamps prefixes each paragraph with its start time as [HH:MM:SS]
+	IncludeTimestamps bool
+}
+
+// Write writes the subtitles to w without formatting tags, separating
+// subtitles with blank lines. Translated text is used when present.
+func (tw *TextWriter) Write(w io.Writer, subtitles []Subtitle) error {
+	writer := bufio.NewWriter(w)
+
+	for i, sub := range subtitles {
+		text := sub.Text
+		if len(sub.Translated) > 0 {
+			text = sub.Translated
+		}
+
+		if i > 0 {
+			fmt.Fprintln(writer)
+		}
+		if tw.IncludeTimestamps {
+			start, err := ParseTimestamp(sub.Start)
+			if err != nil {
+				return fmt.Errorf("subtitle %d: %w", sub.Index, err)
+			}
+			fmt.Fprintf(writer, "[%s] ", formatTextTimestamp(start))
+		}
+		fmt.Fprintln(writer, formattingTag.ReplaceAllString(strings.Join(text, "\n"), ""))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write text output: %w", err)
+	}
+	return nil
+}
+
+// formatTextTimestamp formats a duration as HH:MM:SS
+func formatTextTimestamp(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	secs := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTimestamp parses an SRT timestamp (HH:MM:SS,mmm) into a duration.
+// A period is also accepted as the millisecond separator.
+func ParseTimestamp(ts string) (time.Duration, error) {
+	ts = strings.TrimSpace(ts)
+
+	parts := strings.Split(ts, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid timestamp: %q", ts)
+	}
+
+	secParts := strings.FieldsFunc(parts[2], func(r rune) bool {
+		return r == ',' || r == '.'
+	})
+	if len(secParts) != 2 || len(secParts[1]) != 3 {
+		return 0, fmt.Errorf("invalid timestamp: %q", ts)
+	}
+
+	hours, err := parseDigits(parts[0])
+	if err != nil || hours > maxTimestampHours {
+		return 0, fmt.Errorf("invalid hours in timestamp: %q", ts)
+	}
+	minutes, err := parseDigits(parts[1])
+	if err != nil || minutes > 59 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("invalid minutes in timestamp: %q", ts)
+	}
+	seconds, err := parseDigits(secParts[0])
+	if err != nil || seconds > 59 || len(secParts[0]) != 2 {
+		return 0, fmt.Errorf("invalid seconds in timestamp: %q", ts)
+	}
+	millis, err := parseDigits(secParts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid milliseconds in timestamp: %q", ts)
+	}
+
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(millis)*time.Millisecond, nil
+}
+
+// maxTimestampHours keeps parsed timestamps well within time.Duration
+const maxTimestampHours = 999999
+
+// parseDigits parses a non-empty string of ASCII digits, rejecting the
+// signs and other forms strconv.Atoi accepts
+func parseDigits(s string) (int, error) {
+	if s == "" || len(s) > 9 {
+		return 0, fmt.Errorf("invalid number: %q", s)
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid number: %q", s)
+		}
+	}
+	return strconv.Atoi(s)
+}
+
+// FormatTimestamp formats a duration as an SRT timestamp (HH:MM:SS,mmm)
+func FormatTimestamp(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+	millis := d / time.Millisecond
+
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", hours, minutes, seconds, millis)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"regexp"
+	"testing"
+)
+
+// canonicalTimestamp matches timestamps in the exact form FormatTimestamp
+// produces, which are the inputs that must round-trip unchanged
+var canonicalTimestamp = regexp.MustCompile(`^[0-9]{2}:[0-5][0-9]:[0-5][0-9],[0-9]{3}$`)
+
+func FuzzParseTimestamp(f *testing.F) {
+	for _, seed := range []string{
+		"00:00:00,000",
+		"00:00:01,500",
+		"01:23:45,678",
+		"99:59:59,999",
+		"00:00:02.250",
+		"",
+		"00:00",
+		"00:60:00,000",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		d, err := ParseTimestamp(input)
+		if err != nil {
+			return
+		}
+
+		formatted := FormatTimestamp(d)
+		if canonicalTimestamp.MatchString(input) && formatted != input {
+			t.Errorf("FormatTimestamp(ParseTimestamp(%q)) = %q", input, formatted)
+		}
+
+		again, err := ParseTimestamp(formatted)
+		if err != nil {
+			t.Fatalf("ParseTimestamp(%q) failed on formatted output: %v", formatted, err)
+		}
+		if again != d {
+			t.Errorf("ParseTimestamp(%q) = %v, want %v", formatted, again, d)
+		}
+	})
+}
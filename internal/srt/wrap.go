@@ -0,0 +1,163 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// WrapAlgorithm selects how long subtitle lines are broken
+type WrapAlgorithm string
+
+const (
+	// WrapGreedy breaks at the last space before the limit
+	WrapGreedy WrapAlgorithm = "greedy"
+	// WrapSmart uses the fewest lines possible and balances their lengths
+	WrapSmart WrapAlgorithm = "smart"
+)
+
+// ParseWrapAlgorithm validates a wrap algorithm name
+func ParseWrapAlgorithm(name string) (WrapAlgorithm, error) {
+	switch WrapAlgorithm(name) {
+	case WrapGreedy, WrapSmart:
+		return WrapAlgorithm(name), nil
+	default:
+		return "", fmt.Errorf("unknown word wrap algorithm: %s (expected greedy or smart)", name)
+	}
+}
+
+// WrapLines re-wraps the lines of a subtitle block if any line is longer than maxChars.
+// Dialogue blocks (lines starting with "-") are wrapped line by line so that
+// speakers stay on separate lines; other blocks are joined and wrapped as a whole.
+func WrapLines(lines []string, maxChars int, algorithm WrapAlgorithm) []string {
+	if maxChars <= 0 {
+		return lines
+	}
+
+	tooLong := false
+	dialogue := false
+	for _, line := range lines {
+		if utf8.RuneCountInString(line) > maxChars {
+			tooLong = true
+		}
+		if strings.HasPrefix(line, "-") {
+			dialogue = true
+		}
+	}
+	if !tooLong {
+		return lines
+	}
+
+	if dialogue {
+		var result []string
+		for _, line := range lines {
+			result = append(result, Wrap(line, maxChars, algorithm)...)
+		}
+		return result
+	}
+
+	return Wrap(strings.Join(lines, " "), maxChars, algorithm)
+}
+
+// Wrap breaks text into lines of at most maxChars characters.
+// Words longer than maxChars are placed on their own line.
+func Wrap(text string, maxChars int, algorithm WrapAlgorithm) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if maxChars <= 0 {
+		return []string{strings.Join(words, " ")}
+	}
+
+	greedy := wrapGreedy(words, maxChars)
+	if algorithm != WrapSmart || len(greedy) < 2 {
+		return greedy
+	}
+
+	return wrapBalanced(words, maxChars, len(greedy))
+}
+
+// wrapGreedy fills each line with as many words as fit
+func wrapGreedy(words []string, maxChars int) []string {
+	var lines []string
+	current := words[0]
+	for _, word := range words[1:] {
+		if utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > maxChars {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current += " " + word
+	}
+	return append(lines, current)
+}
+
+// wrapBalanced splits words into exactly lineCount lines no longer than maxChars,
+// minimising the sum of squared line lengths so the lines are as even as possible.
+// It falls back to the greedy result if no such split exists.
+func wrapBalanced(words []string, maxChars, lineCount int) []string {
+	n := len(words)
+	lengths := make([]int, n)
+	for i, word := range words {
+		lengths[i] = utf8.RuneCountInString(word)
+	}
+
+	// lineLen returns the length of a line holding words[i:j]
+	lineLen := func(i, j int) int {
+		length := j - i - 1
+		for k := i; k < j; k++ {
+			length += lengths[k]
+		}
+		return length
+	}
+
+	// cost[l][j] is the minimal cost of placing words[:j] on l lines
+	inf := math.MaxInt
+	cost := make([][]int, lineCount+1)
+	split := make([][]int, lineCount+1)
+	for l := range cost {
+		cost[l] = make([]int, n+1)
+		split[l] = make([]int, n+1)
+		for j := range cost[l] {
+			cost[l][j] = inf
+		}
+	}
+	cost[0][0] = 0
+
+	for l := 1; l <= lineCount; l++ {
+		for j := l; j <= n; j++ {
+			for i := l - 1; i < j; i++ {
+				if cost[l-1][i] == inf {
+					continue
+				}
+				length := lineLen(i, j)
+				// a single over-long word may exceed the limit on its own line
+				if length > maxChars && j-i > 1 {
+					continue
+				}
+				if c := cost[l-1][i] + length*length; c < cost[l][j] {
+					cost[l][j] = c
+					split[l][j] = i
+				}
+			}
+		}
+	}
+
+	if cost[lineCount][n] == inf {
+		return wrapGreedy(words, maxChars)
+	}
+
+	lines := make([]string, lineCount)
+	j := n
+	for l := lineCount; l > 0; l-- {
+		i := split[l][j]
+		lines[l-1] = strings.Join(words[i:j], " ")
+		j = i
+	}
+	return lines
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+)
+
+// WriteMode selects which text of each subtitle is written
+type WriteMode string
+
+const (
+	// WriteModeTranslatedOnly writes the translation, or the original
+	// text for subtitles that have no translation
+	WriteModeTranslatedOnly WriteMode = "translated"
+	// WriteModeOriginalOnly writes the original text
+	WriteModeOriginalOnly WriteMode = "original"
+	// WriteModeBilingual writes the translation followed by the original text
+	WriteModeBilingual WriteMode = "bilingual"
+)
+
+// ParseWriteMode validates a write mode name
+func ParseWriteMode(name string) (WriteMode, error) {
+	switch WriteMode(name) {
+	case WriteModeTranslatedOnly, WriteModeOriginalOnly, WriteModeBilingual:
+		return WriteMode(name), nil
+	default:
+		return "", fmt.Errorf("unknown write mode: %s (expected translated, original or bilingual)", name)
+	}
+}
+
+// LineEnding selects the line terminator of written files
+type LineEnding string
+
+const (
+	// LineEndingLF ends lines with \n
+	LineEndingLF LineEnding = "lf"
+	// LineEndingCRLF ends lines with \r\n
+	LineEndingCRLF LineEnding = "crlf"
+	// LineEndingPlatform uses CRLF on Windows and LF elsewhere
+	LineEndingPlatform LineEnding = "platform"
+)
+
+// ParseLineEnding validates a line ending name; an empty name is LF
+func ParseLineEnding(name string) (LineEnding, error) {
+	switch LineEnding(name) {
+	case "":
+		return LineEndingLF, nil
+	case LineEndingLF, LineEndingCRLF, LineEndingPlatform:
+		return LineEnding(name), nil
+	default:
+		return "", fmt.Errorf("unknown line ending: %s (expected lf, crlf or platform)", name)
+	}
+}
+
+// newline returns the line terminator for e
+func (e LineEnding) newline() string {
+	if e == LineEndingCRLF || (e == LineEndingPlatform && runtime.GOOS == "windows") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// Writer writes subtitles to SRT files
+type Writer struct {
+	Verbose bool
+	// Mode selects which text is written; defaults to WriteModeTranslatedOnly
+	Mode WriteMode
+	// Separator is written on its own line between the translation and
+	// the original text in WriteModeBilingual; no line is written if empty
+	Separator string
+	// AnnotateSource writes the original lines below the translation
+	// as "# Original: <text>" comments for human review
+	AnnotateSource bool
+	// Encoding is the character encoding of written files, UTF-8 if empty.
+	// Characters the encoding cannot represent are written as '?'.
+	Encoding Encoding
+	// LineEnding is the line terminator of written files, LF if empty
+	LineEnding LineEnding
+	// OutputOriginalOnEmpty writes the original text of subtitles without
+	// a translation in WriteModeTranslatedOnly; when false their blocks
+	// are written without text
+	OutputOriginalOnEmpty bool
+}
+
+// NewWriter creates a new SRT writer that writes translations
+func NewWriter(verbose bool) *Writer {
+	return &Writer{
+		Verbose:               verbose,
+		Mode:                  WriteModeTranslatedOnly,
+		OutputOriginalOnEmpty: true,
+	}
+}
+
+// Write saves subtitles to a new SRT file
+func (w *Writer) Write(filename string, subtitles []Subtitle) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	if err := w.WriteWriter(file, subtitles); err != nil {
+		return err
+	}
+
+	if w.Verbose {
+		fmt.Printf("Wrote %d subtitles to %s\n", len(subtitles), filename)
+	}
+
+	return nil
+}
+
+// WriteWriter writes subtitles in SRT format to out
+func (w *Writer) WriteWriter(out io.Writer, subtitles []Subtitle) error {
+	buffered := bufio.NewWriter(out)
+	if w.Encoding == EncodingUTF8BOM {
+		if _, err := buffered.WriteString("\ufeff"); err != nil {
+			return fmt.Errorf("failed to write BOM: %w", err)
+		}
+	}
+
+	if err := w.writeSubtitles(newEncodingWriter(buffered, w.Encoding), subtitles); err != nil {
+		return err
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+	return nil
+}
+
+// writeSubtitles writes subtitles in SRT format to out
+func (w *Writer) writeSubtitles(out io.Writer, subtitles []Subtitle) error {
+	nl := w.LineEnding.newline()
+	for i, sub := range subtitles {
+		// Write subtitle index
+		if _, err := fmt.Fprintf(out, "%d%s", sub.Index, nl); err != nil {
+			return fmt.Errorf("failed to write index: %w", err)
+		}
+
+		// Write timestamps
+		if _, err := fmt.Fprintf(out, "%s --> %s%s", sub.Start, sub.End, nl); err != nil {
+			return fmt.Errorf("failed to write timestamps: %w", err)
+		}
+
+		for _, line := range w.lines(sub) {
+			if _, err := fmt.Fprintf(out, "%s%s", line, nl); err != nil {
+				return fmt.Errorf("failed to write text: %w", err)
+			}
+		}
+
+		// Write the original lines as annotations below the translation
+		if w.AnnotateSource && w.mode() == WriteModeTranslatedOnly && len(sub.Translated) > 0 {
+			for _, line := range sub.Text {
+				if _, err := fmt.Fprintf(out, "%s%s%s", SourceAnnotationPrefix, line, nl); err != nil {
+					return fmt.Errorf("failed to write annotation: %w", err)
+				}
+			}
+		}
+
+		// Add blank line between subtitles (except for last one)
+		if i < len(subtitles)-1 {
+			if _, err := io.WriteString(out, nl); err != nil {
+				return fmt.Errorf("failed to write separator: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// lines returns the text lines of sub to write for the current mode
+func (w *Writer) lines(sub Subtitle) []string {
+	switch w.mode() {
+	case WriteModeOriginalOnly:
+		return sub.Text
+	case WriteModeBilingual:
+		if len(sub.Translated) == 0 {
+			return sub.Text
+		}
+		lines := make([]string, 0, len(sub.Translated)+len(sub.Text)+1)
+		lines = append(lines, sub.Translated...)
+		if w.Separator != "" {
+			lines = append(lines, w.Separator)
+		}
+		return append(lines, sub.Text...)
+	default:
+		// translation, or the original if there is none
+		if len(sub.Translated) > 0 || !w.OutputOriginalOnEmpty {
+			return sub.Translated
+		}
+		return sub.Text
+	}
+}
+
+// mode returns the write mode, defaulting to WriteModeTranslatedOnly
+func (w *Writer) mode() WriteMode {
+	if w.Mode == "" {
+		return WriteModeTranslatedOnly
+	}
+	return w.Mode
+}
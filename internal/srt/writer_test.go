@@ -0,0 +1,113 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package srt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteEmptyTranslation(t *testing.T) {
+	subtitles := []Subtitle{
+		{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"Hello"}, Translated: []string{"Hallo"}},
+		{Index: 2, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"Untranslated"}},
+		{Index: 3, Start: "00:00:05,000", End: "00:00:06,000", Text: []string{"World"}, Translated: []string{"Welt"}},
+		{Index: 4, Start: "00:00:07,000", End: "00:00:08,000", Text: []string{"Also untranslated"}},
+	}
+
+	tests := []struct {
+		name                  string
+		outputOriginalOnEmpty bool
+		want                  [][]string
+	}{
+		{
+			name:                  "original on empty",
+			outputOriginalOnEmpty: true,
+			want:                  [][]string{{"Hallo"}, {"Untranslated"}, {"Welt"}, {"Also untranslated"}},
+		},
+		{
+			name:                  "empty blocks",
+			outputOriginalOnEmpty: false,
+			want:                  [][]string{{"Hallo"}, {}, {"Welt"}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := NewWriter(false)
+			writer.OutputOriginalOnEmpty = tt.outputOriginalOnEmpty
+
+			var buf bytes.Buffer
+			if err := writer.WriteWriter(&buf, subtitles); err != nil {
+				t.Fatalf("WriteWriter() error = %v", err)
+			}
+
+			parsed, err := NewParser(false).ParseReader(&buf)
+			if err != nil {
+				t.Fatalf("ParseReader() error = %v", err)
+			}
+			if len(parsed) != len(subtitles) {
+				t.Fatalf("parsed %d blocks, want %d", len(parsed), len(subtitles))
+			}
+			for i, sub := range parsed {
+				if sub.Index != subtitles[i].Index || sub.Start != subtitles[i].Start || sub.End != subtitles[i].End {
+					t.Errorf("block %d = %d %s --> %s, want %d %s --> %s", i+1,
+						sub.Index, sub.Start, sub.End, subtitles[i].Index, subtitles[i].Start, subtitles[i].End)
+				}
+				if got, want := strings.Join(sub.Text, "\n"), strings.Join(tt.want[i], "\n"); got != want {
+					t.Errorf("block %d text = %q, want %q", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteEncoding(t *testing.T) {
+	subtitles := []Subtitle{
+		{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"x"}, Translated: []string{"€5 für “dich” 日"}},
+	}
+
+	tests := []struct {
+		encoding Encoding
+		want     string
+		issues   []EncodingIssue
+	}{
+		{
+			encoding: EncodingCP1252,
+			want:     "\x805 f\xfcr \x93dich\x94 ?",
+			issues:   []EncodingIssue{{Index: 1, Char: '日'}},
+		},
+		{
+			encoding: EncodingLatin1,
+			want:     "?5 f\xfcr ?dich? ?",
+			issues:   []EncodingIssue{{Index: 1, Char: '€'}, {Index: 1, Char: '“'}, {Index: 1, Char: '”'}, {Index: 1, Char: '日'}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			writer := NewWriter(false)
+			writer.Encoding = tt.encoding
+			var out bytes.Buffer
+			if err := writer.WriteWriter(&out, subtitles); err != nil {
+				t.Fatalf("WriteWriter() error = %v", err)
+			}
+			want := "1\n00:00:01,000 --> 00:00:02,000\n" + tt.want + "\n"
+			if out.String() != want {
+				t.Errorf("WriteWriter() = %q, want %q", out.String(), want)
+			}
+
+			issues := FindUnencodable(subtitles, tt.encoding)
+			if len(issues) != len(tt.issues) {
+				t.Fatalf("FindUnencodable() = %v, want %v", issues, tt.issues)
+			}
+			for i := range issues {
+				if issues[i] != tt.issues[i] {
+					t.Errorf("FindUnencodable()[%d] = %v, want %v", i, issues[i], tt.issues[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package telemetry sends opt-in, anonymous usage statistics. Payloads
+// never contain API keys, file paths or subtitle text.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// timeout bounds how long sending telemetry can delay exit
+const timeout = 5 * time.Second
+
+// Payload is the anonymous summary of a successful translation
+type Payload struct {
+	Backend string `json:"backend"`
+	// ModelHash is the SHA-256 of the model name, so private or
+	// fine-tuned model names are not disclosed
+	ModelHash string `json:"model_hash"`
+	// Subtitles is the subtitle count rounded to the nearest 100
+	Subtitles int `json:"subtitles"`
+	// Elapsed is a coarse duration bucket such as "1m-5m"
+	Elapsed       string `json:"elapsed"`
+	GoVersion     string `json:"go_version"`
+	OS            string `json:"os"`
+	SRTranVersion string `json:"srtran_version"`
+}
+
+// NewPayload creates an anonymized payload for a translation run
+func NewPayload(backend, model string, subtitles int, elapsed time.Duration, version string) Payload {
+	sum := sha256.Sum256([]byte(model))
+	return Payload{
+		Backend:       backend,
+		ModelHash:     hex.EncodeToString(sum[:]),
+		Subtitles:     (subtitles + 50) / 100 * 100,
+		Elapsed:       elapsedBucket(elapsed),
+		GoVersion:     runtime.Version(),
+		OS:            runtime.GOOS,
+		SRTranVersion: version,
+	}
+}
+
+// elapsedBucket returns the bucket d falls in
+func elapsedBucket(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < 5*time.Minute:
+		return "1m-5m"
+	case d < 15*time.Minute:
+		return "5m-15m"
+	case d < time.Hour:
+		return "15m-1h"
+	default:
+		return ">1h"
+	}
+}
+
+// Send posts the payload to url once; failures are not retried
+func Send(ctx context.Context, url string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
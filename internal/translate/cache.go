@@ -0,0 +1,131 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/s0up4200/SRTran/internal/cache"
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// CachingTranslator wraps a Translator with the translation cache so only
+// subtitles missing from the cache are passed to it
+type CachingTranslator struct {
+	inner Translator
+	cache *cache.Cache
+	// backend and model are part of the cache key, so switching models
+	// does not reuse translations made by another one. model returns the
+	// model in use, for lookups and for subtitles not stamped with the
+	// model that translated them.
+	backend Backend
+	model   func() string
+	logger  zerolog.Logger
+}
+
+var _ Translator = (*CachingTranslator)(nil)
+
+// NewCachingTranslator returns a CachingTranslator storing the
+// translations of inner, made on backend, in c. model returns the model
+// inner currently uses, such as Service.CurrentModel.
+func NewCachingTranslator(inner Translator, c *cache.Cache, backend Backend, model func() string, logger zerolog.Logger) *CachingTranslator {
+	return &CachingTranslator{inner: inner, cache: c, backend: backend, model: model, logger: logger}
+}
+
+// Translate returns the cached translation of each subtitle, translating
+// the rest with the wrapped Translator and caching the results. The
+// subtitles are returned in their original order. A *PartialError from
+// the wrapped Translator is returned with the result. Failing to save the
+// cache is logged, not returned.
+func (t *CachingTranslator) Translate(ctx context.Context, subs []srt.Subtitle, src, tgt string) ([]srt.Subtitle, error) {
+	key := func(model string, sub srt.Subtitle) string {
+		return cache.Key(string(t.backend), model, src, tgt, strings.Join(sub.Text, "\n"))
+	}
+
+	// saved on every path so the hits counted by Get are kept even when
+	// nothing new is translated
+	defer func() {
+		if err := t.cache.Save(); err != nil {
+			t.logger.Warn().Err(err).Msg("failed to save translation cache")
+		}
+	}()
+
+	model := t.model()
+	result := make([]srt.Subtitle, len(subs))
+	var misses []srt.Subtitle
+	var missIndexes []int
+	for i, sub := range subs {
+		result[i] = sub
+		if translation, ok := t.cache.Get(key(model, sub)); ok {
+			result[i].Translated = translation
+			continue
+		}
+		misses = append(misses, sub)
+		missIndexes = append(missIndexes, i)
+	}
+
+	t.logger.Info().
+		Int("cached", len(subs)-len(misses)).
+		Int("uncached", len(misses)).
+		Msg("translation cache lookup")
+	if len(misses) == 0 {
+		return result, nil
+	}
+
+	translated, err := t.inner.Translate(ctx, misses, src, tgt)
+	var partial *PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+	if len(translated) != len(misses) {
+		return nil, fmt.Errorf("translator returned %d subtitles, expected %d", len(translated), len(misses))
+	}
+
+	for i, sub := range translated {
+		result[missIndexes[i]] = sub
+		// failed batches are untranslated or hold placeholder text
+		if len(sub.Translated) > 0 && !inFailedBatch(partial, sub.Index) {
+			// key on the model that translated the subtitle, which is not
+			// the current one when a fallback model took over mid-run
+			translatedBy, ok := sub.Metadata[modelMetadataKey].(string)
+			if !ok {
+				translatedBy = t.model()
+			}
+			t.cache.Put(key(translatedBy, misses[i]), sub.Translated)
+		}
+	}
+
+	if partial != nil {
+		return result, partial
+	}
+	return result, nil
+}
+
+// inFailedBatch reports whether the subtitle numbered index is in one of
+// the failed batches of partial, which may be nil
+func inFailedBatch(partial *PartialError, index int) bool {
+	if partial == nil {
+		return false
+	}
+	for _, batch := range partial.Failed {
+		if index >= batch.FirstIndex && index <= batch.LastIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// HealthCheck checks the wrapped Translator
+func (t *CachingTranslator) HealthCheck(ctx context.Context) error {
+	return t.inner.HealthCheck(ctx)
+}
+
+// Close closes the wrapped Translator
+func (t *CachingTranslator) Close() {
+	t.inner.Close()
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/s0up4200/SRTran/internal/cache"
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// upperTranslator translates each subtitle into upper case and counts
+// the subtitles it was asked to translate
+type upperTranslator struct {
+	translated int
+}
+
+func (u *upperTranslator) Translate(_ context.Context, subs []srt.Subtitle, _, _ string) ([]srt.Subtitle, error) {
+	result := make([]srt.Subtitle, len(subs))
+	for i, sub := range subs {
+		result[i] = sub
+		result[i].Translated = []string{strings.ToUpper(strings.Join(sub.Text, " "))}
+	}
+	u.translated += len(subs)
+	return result, nil
+}
+
+func (u *upperTranslator) HealthCheck(context.Context) error { return nil }
+
+func (u *upperTranslator) Close() {}
+
+func TestCachingTranslatorSavesHits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	subs := []srt.Subtitle{
+		{Index: 1, Start: "00:00:01,000", End: "00:00:02,000", Text: []string{"hello"}},
+		{Index: 2, Start: "00:00:03,000", End: "00:00:04,000", Text: []string{"world"}},
+	}
+	model := func() string { return "model" }
+
+	// each run opens the cache file again, as separate srtran runs do
+	run := func() *upperTranslator {
+		c, err := cache.Open(path, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inner := &upperTranslator{}
+		translator := NewCachingTranslator(inner, c, BackendOpenAI, model, zerolog.Nop())
+		got, err := translator.Translate(context.Background(), subs, "en", "de")
+		if err != nil {
+			t.Fatalf("Translate() error = %v", err)
+		}
+		if got[0].Translated[0] != "HELLO" || got[1].Translated[0] != "WORLD" {
+			t.Errorf("Translate() = %v, want HELLO and WORLD", got)
+		}
+		return inner
+	}
+
+	if inner := run(); inner.translated != 2 {
+		t.Errorf("first run translated %d subtitles, want 2", inner.translated)
+	}
+	if inner := run(); inner.translated != 0 {
+		t.Errorf("fully cached run translated %d subtitles, want 0", inner.translated)
+	}
+
+	c, err := cache.Open(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Hits != 2 || c.Misses != 2 {
+		t.Errorf("saved hits = %d, misses = %d, want 2 and 2", c.Hits, c.Misses)
+	}
+}
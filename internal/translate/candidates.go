@@ -0,0 +1,85 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// CandidateChooser picks one of several candidate translations of a
+// subtitle and returns its index in candidates
+type CandidateChooser func(original srt.Subtitle, candidates [][]string) int
+
+// translationRequestKey is the context key that marks the requests of
+// Translate's batches. Only those use NCandidates and UseJSONSchema;
+// tone detection, paraphrasing and other prompts want one plain reply.
+type translationRequestKey struct{}
+
+// isTranslationRequest reports whether ctx is marked with
+// translationRequestKey
+func isTranslationRequest(ctx context.Context) bool {
+	marked, _ := ctx.Value(translationRequestKey{}).(bool)
+	return marked
+}
+
+// sendCandidates is send for a batch of subtitles. With NCandidates > 1 on
+// the OpenAI backend, each subtitle's translation of a translation
+// request is picked from the returned choices.
+func (s *Service) sendCandidates(ctx context.Context, prompt string, subtitles []srt.Subtitle, temperature float32) ([][]string, error) {
+	if s.config.Backend != BackendOpenAI || s.config.NCandidates <= 1 || !isTranslationRequest(ctx) {
+		return s.send(ctx, prompt, len(subtitles), temperature)
+	}
+
+	candidates, err := s.openAICandidates(ctx, prompt, len(subtitles), temperature)
+	if err != nil {
+		return nil, err
+	}
+	return s.chooseCandidates(subtitles, candidates), nil
+}
+
+// chooseCandidates returns the first choice with each subtitle replaced by
+// the candidate picked by ChooseCandidate. Without a chooser the
+// alternatives are only logged. Choices with a different number of blocks
+// than the first cannot be matched to subtitles and are ignored.
+func (s *Service) chooseCandidates(subtitles []srt.Subtitle, candidates [][][]string) [][]string {
+	chosen := candidates[0]
+
+	for i := range chosen {
+		if i >= len(subtitles) {
+			break
+		}
+
+		options := [][]string{chosen[i]}
+		seen := map[string]bool{strings.Join(chosen[i], "\n"): true}
+		for _, candidate := range candidates[1:] {
+			if len(candidate) != len(chosen) {
+				continue
+			}
+			key := strings.Join(candidate[i], "\n")
+			if !seen[key] {
+				seen[key] = true
+				options = append(options, candidate[i])
+			}
+		}
+		if len(options) == 1 {
+			continue
+		}
+
+		if s.config.ChooseCandidate == nil {
+			s.logger.Debug().
+				Int("index", subtitles[i].Index).
+				Interface("alternatives", options[1:]).
+				Msg("alternative translations")
+			continue
+		}
+
+		if pick := s.config.ChooseCandidate(subtitles[i], options); pick >= 0 && pick < len(options) {
+			chosen[i] = options[pick]
+		}
+	}
+	return chosen
+}
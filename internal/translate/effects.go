@@ -0,0 +1,82 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// EffectsMode selects the language of sound-effect annotations such as
+// [MUSIC PLAYS] in translations
+type EffectsMode string
+
+const (
+	// EffectsTranslated translates annotations into the target language
+	EffectsTranslated EffectsMode = "translated"
+	// EffectsOriginal keeps annotations in the source language
+	EffectsOriginal EffectsMode = "original"
+)
+
+// effectsRules is rule 2 of translationPrompt for each EffectsMode
+var effectsRules = map[EffectsMode]string{
+	EffectsTranslated: "Maintain original line breaks and formatting tags (e.g., <i>); translate sound-effect annotations in square brackets into the target language, keeping the brackets and their capitalization (e.g., [MUSIC PLAYS])",
+	EffectsOriginal:   "Maintain original line breaks and formatting symbols (e.g., <i>, [music]); keep sound-effect annotations in square brackets exactly as in the original",
+}
+
+// annotationPattern matches a square-bracketed annotation. Placeholder
+// markers like [%1] are not annotations.
+var annotationPattern = regexp.MustCompile(`\[[^\]%]+\]`)
+
+// EffectIssue is a subtitle whose translated annotations are not in the
+// language the EffectsMode asks for
+type EffectIssue struct {
+	Index  int
+	Reason string
+}
+
+// checkEffects compares the annotations of each subtitle with those of its
+// translation. With EffectsOriginal they must be unchanged; with
+// EffectsTranslated an annotation copied verbatim is still in the source
+// language. Subtitles are only checked when the source and target differ.
+func checkEffects(subtitles []srt.Subtitle, mode EffectsMode) []EffectIssue {
+	var issues []EffectIssue
+	for _, sub := range subtitles {
+		if len(sub.Translated) == 0 {
+			continue
+		}
+		original := annotationPattern.FindAllString(strings.Join(sub.Text, "\n"), -1)
+		if len(original) == 0 {
+			continue
+		}
+		translated := annotationPattern.FindAllString(strings.Join(sub.Translated, "\n"), -1)
+		if len(translated) != len(original) {
+			issues = append(issues, EffectIssue{
+				Index:  sub.Index,
+				Reason: fmt.Sprintf("expected %d annotations, found %d", len(original), len(translated)),
+			})
+			continue
+		}
+
+		for i := range original {
+			same := strings.EqualFold(original[i], translated[i])
+			switch {
+			case mode == EffectsOriginal && !same:
+				issues = append(issues, EffectIssue{
+					Index:  sub.Index,
+					Reason: fmt.Sprintf("annotation %s was changed to %s", original[i], translated[i]),
+				})
+			case mode != EffectsOriginal && same:
+				issues = append(issues, EffectIssue{
+					Index:  sub.Index,
+					Reason: fmt.Sprintf("annotation %s was not translated", original[i]),
+				})
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrRequestTimeout is wrapped by errors of requests that took longer
+// than ServiceConfig.RequestTimeout
+var ErrRequestTimeout = errors.New("request timed out")
+
+// TranslationError describes a failed batch translation.
+// Use errors.As to extract it from errors returned by Service.Translate.
+type TranslationError struct {
+	// BatchStart and BatchEnd are the positions of the batch in the
+	// input slice (end exclusive)
+	BatchStart int
+	BatchEnd   int
+	// Attempt is the 1-based attempt that failed, or 0 if unknown
+	Attempt int
+	// SubtitleIndex is the index of the first affected subtitle, or 0 if
+	// the error applies to the whole batch
+	SubtitleIndex int
+	Backend       Backend
+	Underlying    error
+}
+
+// Error implements the error interface
+func (e *TranslationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to translate batch %d-%d", e.BatchStart, e.BatchEnd)
+
+	var details []string
+	if e.Backend != "" {
+		details = append(details, "backend "+string(e.Backend))
+	}
+	if e.Attempt > 0 {
+		details = append(details, fmt.Sprintf("attempt %d", e.Attempt))
+	}
+	if e.SubtitleIndex > 0 {
+		details = append(details, fmt.Sprintf("subtitle %d", e.SubtitleIndex))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+
+	if e.Underlying != nil {
+		fmt.Fprintf(&b, ": %v", e.Underlying)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error
+func (e *TranslationError) Unwrap() error {
+	return e.Underlying
+}
+
+// newTranslationError creates a TranslationError for the batch [start, end)
+func (s *Service) newTranslationError(start, end, attempt int, err error) *TranslationError {
+	return &TranslationError{
+		BatchStart: start,
+		BatchEnd:   end,
+		Attempt:    attempt,
+		Backend:    s.config.Backend,
+		Underlying: err,
+	}
+}
+
+// FailedBatch is a batch that ContinueOnError left untranslated
+type FailedBatch struct {
+	// FirstIndex and LastIndex are the subtitle indexes of the batch
+	FirstIndex int
+	LastIndex  int
+	Err        error
+}
+
+// PartialError is returned by Service.Translate together with the
+// translated subtitles when ContinueOnError skipped failed batches. The
+// subtitles of failed batches have no translation.
+type PartialError struct {
+	Failed []FailedBatch
+}
+
+// Error implements the error interface
+func (e *PartialError) Error() string {
+	ranges := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		ranges[i] = fmt.Sprintf("%d-%d", f.FirstIndex, f.LastIndex)
+	}
+	return fmt.Sprintf("%d batch(es) failed (subtitles %s)", len(e.Failed), strings.Join(ranges, ", "))
+}
+
+// Unwrap returns the errors of the failed batches
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f.Err
+	}
+	return errs
+}
@@ -11,14 +11,46 @@ import (
 	"google.golang.org/genai"
 )
 
-func (s *Service) translateWithGoogleAI(ctx context.Context, text string, sourceLang, targetLang string) ([][]string, error) {
-	if s.config.Model == "" {
-		return nil, fmt.Errorf("model must be specified for Google AI backend")
+// DefaultSafetyThreshold is the Google AI safety filter level used when
+// ServiceConfig.SafetyThreshold is not set
+const DefaultSafetyThreshold = "block_only_high"
+
+// safetyThresholds maps SafetyThreshold values to genai thresholds
+var safetyThresholds = map[string]genai.HarmBlockThreshold{
+	"block_none":             genai.HarmBlockThresholdBlockNone,
+	"block_only_high":        genai.HarmBlockThresholdBlockOnlyHigh,
+	"block_medium_and_above": genai.HarmBlockThresholdBlockMediumAndAbove,
+	"block_low_and_above":    genai.HarmBlockThresholdBlockLowAndAbove,
+}
+
+// safetySettings applies threshold to every harm category. Films routinely
+// contain violence, drugs and adult themes, which the stricter default
+// filters block.
+func safetySettings(threshold string) ([]*genai.SafetySetting, error) {
+	t, ok := safetyThresholds[threshold]
+	if !ok {
+		return nil, fmt.Errorf("unknown safety threshold %q (want block_none, block_only_high, block_medium_and_above or block_low_and_above)", threshold)
 	}
 
-	prompt := fmt.Sprintf(translationPrompt, sourceLang, targetLang, text)
+	categories := []genai.HarmCategory{
+		genai.HarmCategoryHarassment,
+		genai.HarmCategoryHateSpeech,
+		genai.HarmCategorySexuallyExplicit,
+		genai.HarmCategoryDangerousContent,
+	}
+	settings := make([]*genai.SafetySetting, len(categories))
+	for i, category := range categories {
+		settings[i] = &genai.SafetySetting{Category: category, Threshold: t}
+	}
+	return settings, nil
+}
 
-	maxAttempts := 5
+func (s *Service) translateWithGoogleAI(ctx context.Context, prompt string, temperature float32) ([][]string, error) {
+	if s.requestModel(ctx) == "" {
+		return nil, fmt.Errorf("model must be specified for Google AI backend")
+	}
+
+	maxAttempts := s.maxAttempts(5)
 	var lastErr error
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
@@ -27,7 +59,15 @@ func (s *Service) translateWithGoogleAI(ctx context.Context, text string, source
 			return nil, fmt.Errorf("rate limit wait interrupted: %w", err)
 		}
 
-		result, err := s.googleClient.Models.GenerateContent(ctx, s.config.Model, genai.Text(prompt), nil)
+		config := &genai.GenerateContentConfig{SafetySettings: s.safetySettings}
+		if temperature > 0 {
+			config.Temperature = genai.Ptr(float64(temperature))
+		}
+		if s.config.Grounding {
+			config.Tools = []*genai.Tool{{GoogleSearch: &genai.GoogleSearch{}}}
+		}
+
+		result, err := s.googleClient.Models.GenerateContent(ctx, s.requestModel(ctx), genai.Text(prompt), config)
 		if err != nil {
 			// check for rate limit errors
 			if strings.Contains(err.Error(), "quota") ||
@@ -65,9 +105,7 @@ func (s *Service) translateWithGoogleAI(ctx context.Context, text string, source
 			}
 
 			// remove the [N] prefix
-			if idx := strings.Index(t, "]\n"); idx != -1 {
-				t = strings.TrimSpace(t[idx+2:])
-			}
+			t = stripMarker(ctx, t)
 
 			// split by natural line breaks
 			lines := strings.Split(t, "\n")
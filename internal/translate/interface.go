@@ -0,0 +1,36 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// Translator translates subtitles. Service implements it; other
+// implementations can wrap a Translator to add behavior such as caching.
+type Translator interface {
+	// Translate returns subtitles with Translated set from src to tgt
+	Translate(ctx context.Context, subs []srt.Subtitle, src, tgt string) ([]srt.Subtitle, error)
+	// HealthCheck reports whether the backend can be reached and accepts
+	// the configured credentials and model
+	HealthCheck(ctx context.Context) error
+	// Close releases the resources of the translator
+	Close()
+}
+
+var _ Translator = (*Service)(nil)
+
+// healthCheckPrompt is the minimal request sent by HealthCheck
+const healthCheckPrompt = "Reply with only the word OK."
+
+// HealthCheck sends a minimal request to the backend
+func (s *Service) HealthCheck(ctx context.Context) error {
+	if _, err := s.send(ctx, healthCheckPrompt, 1, 0); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// Line limit modes for ServiceConfig.LineLimitMode
+const (
+	// LineLimitWarn logs translated lines longer than MaxCharsPerLine
+	LineLimitWarn = "warn"
+	// LineLimitWrap re-wraps them with WrapAlgorithm
+	LineLimitWrap = "wrap"
+	// LineLimitTruncate cuts them at MaxCharsPerLine
+	LineLimitTruncate = "truncate"
+)
+
+// validateLineLimitMode returns an error for unknown line limit modes
+func validateLineLimitMode(mode string) error {
+	switch mode {
+	case LineLimitWarn, LineLimitWrap, LineLimitTruncate:
+		return nil
+	default:
+		return fmt.Errorf("unknown line limit mode %q (want warn, wrap or truncate)", mode)
+	}
+}
+
+// applyLineLimit handles translated lines longer than MaxCharsPerLine
+// according to LineLimitMode
+func (s *Service) applyLineLimit(subtitles []srt.Subtitle) {
+	limit := s.config.MaxCharsPerLine
+	for i, sub := range subtitles {
+		switch s.config.LineLimitMode {
+		case LineLimitWrap:
+			subtitles[i].Translated = srt.WrapLines(sub.Translated, limit, s.config.WrapAlgorithm)
+		case LineLimitTruncate:
+			lines := make([]string, len(sub.Translated))
+			for j, line := range sub.Translated {
+				lines[j] = truncateLine(line, limit)
+			}
+			subtitles[i].Translated = lines
+		default:
+			longest := 0
+			for _, line := range sub.Translated {
+				longest = max(longest, utf8.RuneCountInString(line))
+			}
+			if longest > limit {
+				s.logger.Warn().
+					Int("index", sub.Index).
+					Int("chars", longest).
+					Int("limit", limit).
+					Msg("translated line is longer than the limit")
+			}
+		}
+	}
+}
+
+// truncateLine returns the first limit characters of line
+func truncateLine(line string, limit int) string {
+	if utf8.RuneCountInString(line) <= limit {
+		return line
+	}
+	return string([]rune(line)[:limit])
+}
@@ -6,13 +6,73 @@ package translate
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	openai "github.com/sashabaranov/go-openai"
 )
 
-func (s *Service) translateWithLMStudio(ctx context.Context, text string, sourceLang, targetLang string) ([][]string, error) {
-	if s.config.Model == "" {
+// DefaultLMStudioBaseURL is the API endpoint of a local LM Studio server
+const DefaultLMStudioBaseURL = "http://localhost:1234/v1"
+
+// DefaultLMStudioKeepAlive is how long, in seconds, LM Studio is asked to
+// keep the model loaded after a request
+const DefaultLMStudioKeepAlive = 300
+
+// lmStudioTransport adds "keep_alive" to request bodies, which go-openai
+// has no field for
+type lmStudioTransport struct {
+	base      http.RoundTripper
+	keepAlive int
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *lmStudioTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Method == http.MethodPost {
+		req = req.Clone(req.Context())
+		if err := setBodyFields(req, map[string]interface{}{"keep_alive": t.keepAlive}); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// newLMStudioHTTPClient creates an HTTP client that asks LM Studio to keep
+// the model loaded, or nil for the default client when LMStudioKeepAlive
+// is 0
+func newLMStudioHTTPClient(config ServiceConfig) *http.Client {
+	if config.LMStudioKeepAlive <= 0 {
+		return nil
+	}
+	return &http.Client{
+		Transport: &lmStudioTransport{base: http.DefaultTransport, keepAlive: config.LMStudioKeepAlive},
+	}
+}
+
+// LMStudioModels returns the IDs of the models available on the LM Studio
+// server at baseURL, in the order the server lists them. apiKey may be
+// empty.
+func LMStudioModels(ctx context.Context, baseURL, apiKey string) ([]string, error) {
+	if baseURL == "" {
+		baseURL = DefaultLMStudioBaseURL
+	}
+	clientConfig := openai.DefaultConfig(apiKey)
+	clientConfig.BaseURL = baseURL
+
+	list, err := openai.NewClientWithConfig(clientConfig).ListModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LM Studio models: %w", err)
+	}
+
+	models := make([]string, len(list.Models))
+	for i, model := range list.Models {
+		models[i] = model.ID
+	}
+	return models, nil
+}
+
+func (s *Service) translateWithLMStudio(ctx context.Context, prompt string, expectedCount int, temperature float32) ([][]string, error) {
+	if s.requestModel(ctx) == "" {
 		return nil, fmt.Errorf("model must be specified for LM Studio backend")
 	}
 
@@ -27,11 +87,12 @@ func (s *Service) translateWithLMStudio(ctx context.Context, text string, source
 	resp, err := s.openaiClient.CreateChatCompletion(
 		ctx,
 		openai.ChatCompletionRequest{
-			Model: s.config.Model,
+			Model:       s.requestModel(ctx),
+			Temperature: temperature,
 			Messages: []openai.ChatCompletionMessage{
 				{
 					Role:    openai.ChatMessageRoleSystem,
-					Content: fmt.Sprintf(translationPrompt, sourceLang, targetLang, text),
+					Content: prompt,
 				},
 			},
 		},
@@ -40,12 +101,11 @@ func (s *Service) translateWithLMStudio(ctx context.Context, text string, source
 		return nil, fmt.Errorf("failed to translate batch: %w", err)
 	}
 
+	s.observeRateLimit(resp.GetRateLimitHeaders())
+
 	// split response by subtitle separator
 	translations := strings.Split(resp.Choices[0].Message.Content, "===SUBTITLE===")
 
-	// Count how many translations we expect by counting "[N]" in the input text
-	expectedCount := strings.Count(text, "[")
-
 	var cleanTranslations [][]string
 	for i, t := range translations {
 		t = strings.TrimSpace(t)
@@ -59,9 +119,7 @@ func (s *Service) translateWithLMStudio(ctx context.Context, text string, source
 		}
 
 		// remove the [N] prefix
-		if idx := strings.Index(t, "]\n"); idx != -1 {
-			t = strings.TrimSpace(t[idx+2:])
-		}
+		t = stripMarker(ctx, t)
 
 		// split by natural line breaks
 		lines := strings.Split(t, "\n")
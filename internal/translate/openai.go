@@ -5,14 +5,59 @@ package translate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/jsonschema"
 )
 
-func (s *Service) translateWithOpenAI(ctx context.Context, text string, sourceLang, targetLang string) ([][]string, error) {
-	if s.config.Model == "" {
+// translationsSchema is the structured output format requested when
+// UseJSONSchema is set
+var translationsSchema = &jsonschema.Definition{
+	Type: jsonschema.Object,
+	Properties: map[string]jsonschema.Definition{
+		"translations": {
+			Type: jsonschema.Array,
+			Items: &jsonschema.Definition{
+				Type: jsonschema.Object,
+				Properties: map[string]jsonschema.Definition{
+					"index": {Type: jsonschema.Integer, Description: "subtitle number N from the [N] marker"},
+					"lines": {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+				},
+				Required:             []string{"index", "lines"},
+				AdditionalProperties: false,
+			},
+		},
+	},
+	Required:             []string{"translations"},
+	AdditionalProperties: false,
+}
+
+// jsonTranslations is a response in the translationsSchema format
+type jsonTranslations struct {
+	Translations []struct {
+		Index int      `json:"index"`
+		Lines []string `json:"lines"`
+	} `json:"translations"`
+}
+
+func (s *Service) translateWithOpenAI(ctx context.Context, prompt string, count int, temperature float32) ([][]string, error) {
+	candidates, err := s.openAICandidates(ctx, prompt, count, temperature)
+	if err != nil {
+		return nil, err
+	}
+	return candidates[0], nil
+}
+
+// openAICandidates requests the completions of prompt and returns each
+// split into subtitle blocks, the first choice first; count is the number
+// of blocks expected. Translation requests ask for NCandidates choices
+// and, with UseJSONSchema, structured outputs; other requests for one
+// plain choice.
+func (s *Service) openAICandidates(ctx context.Context, prompt string, count int, temperature float32) ([][][]string, error) {
+	if s.requestModel(ctx) == "" {
 		return nil, fmt.Errorf("model must be specified for OpenAI backend")
 	}
 
@@ -20,24 +65,87 @@ func (s *Service) translateWithOpenAI(ctx context.Context, text string, sourceLa
 		return nil, fmt.Errorf("rate limit wait interrupted: %w", err)
 	}
 
-	resp, err := s.openaiClient.CreateChatCompletion(
-		ctx,
-		openai.ChatCompletionRequest{
-			Model: s.config.Model,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: fmt.Sprintf(translationPrompt, sourceLang, targetLang, text),
-				},
+	structured := s.config.UseJSONSchema && isTranslationRequest(ctx)
+	request := openai.ChatCompletionRequest{
+		Model:       s.requestModel(ctx),
+		Temperature: temperature,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: prompt,
 			},
 		},
-	)
+	}
+	if isTranslationRequest(ctx) {
+		request.N = s.config.NCandidates
+	}
+	if structured {
+		request.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   "subtitle_translations",
+				Schema: translationsSchema,
+				Strict: true,
+			},
+		}
+	}
+
+	resp, err := s.openaiClient.CreateChatCompletion(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to translate batch: %w", err)
 	}
 
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("empty response from OpenAI")
+	}
+
+	s.observeRateLimit(resp.GetRateLimitHeaders())
+
+	candidates := make([][][]string, len(resp.Choices))
+	for i, choice := range resp.Choices {
+		if !structured {
+			candidates[i] = splitTranslations(ctx, choice.Message.Content)
+			continue
+		}
+		if candidates[i], err = parseJSONTranslations(choice.Message.Content, count); err != nil {
+			return nil, err
+		}
+	}
+	return candidates, nil
+}
+
+// parseJSONTranslations returns the lines of each of the count subtitle
+// blocks of a translationsSchema response, placed by index. Indexes out
+// of 1..count, duplicated or missing are an error.
+func parseJSONTranslations(content string, count int) ([][]string, error) {
+	var response jsonTranslations
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse structured output: %w", err)
+	}
+
+	translations := make([][]string, count)
+	for _, t := range response.Translations {
+		if t.Index < 1 || t.Index > count {
+			return nil, fmt.Errorf("structured output index %d out of range 1..%d", t.Index, count)
+		}
+		if translations[t.Index-1] != nil {
+			return nil, fmt.Errorf("structured output has index %d more than once", t.Index)
+		}
+		// an empty translation still counts as placed
+		translations[t.Index-1] = append([]string{}, t.Lines...)
+	}
+	for i, lines := range translations {
+		if lines == nil {
+			return nil, fmt.Errorf("structured output is missing index %d", i+1)
+		}
+	}
+	return translations, nil
+}
+
+// splitTranslations splits a response into the lines of each subtitle block
+func splitTranslations(ctx context.Context, content string) [][]string {
 	// split response by subtitle separator
-	translations := strings.Split(resp.Choices[0].Message.Content, "===SUBTITLE===")
+	translations := strings.Split(content, "===SUBTITLE===")
 
 	var cleanTranslations [][]string
 	for _, t := range translations {
@@ -47,9 +155,7 @@ func (s *Service) translateWithOpenAI(ctx context.Context, text string, sourceLa
 		}
 
 		// remove the [N] prefix
-		if idx := strings.Index(t, "]\n"); idx != -1 {
-			t = strings.TrimSpace(t[idx+2:])
-		}
+		t = stripMarker(ctx, t)
 
 		// split by natural line breaks
 		lines := strings.Split(t, "\n")
@@ -58,5 +164,5 @@ func (s *Service) translateWithOpenAI(ctx context.Context, text string, sourceLa
 		}
 	}
 
-	return cleanTranslations, nil
+	return cleanTranslations
 }
@@ -0,0 +1,138 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func TestParseJSONTranslations(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name:    "in order",
+			content: `{"translations":[{"index":1,"lines":["A"]},{"index":2,"lines":["B","b"]}]}`,
+			want:    [][]string{{"A"}, {"B", "b"}},
+		},
+		{
+			name:    "out of order",
+			content: `{"translations":[{"index":2,"lines":["B"]},{"index":1,"lines":["A"]}]}`,
+			want:    [][]string{{"A"}, {"B"}},
+		},
+		{
+			name:    "empty lines",
+			content: `{"translations":[{"index":1,"lines":[]},{"index":2,"lines":["B"]}]}`,
+			want:    [][]string{{}, {"B"}},
+		},
+		{
+			name:    "gap",
+			content: `{"translations":[{"index":1,"lines":["A"]},{"index":3,"lines":["C"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicate",
+			content: `{"translations":[{"index":1,"lines":["A"]},{"index":1,"lines":["B"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "zero index",
+			content: `{"translations":[{"index":0,"lines":["A"]},{"index":1,"lines":["B"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing entry",
+			content: `{"translations":[{"index":2,"lines":["B"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			content: `[1]\nA`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJSONTranslations(tt.content, 2)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseJSONTranslations() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseJSONTranslations() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJSONTranslations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAIStructuredOutputsOnlyForTranslations(t *testing.T) {
+	var requests []map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		requests = append(requests, body)
+
+		content := "casual"
+		if _, ok := body["response_format"]; ok {
+			content = `{"translations":[{"index":1,"lines":["Hallo"]}]}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: content}}},
+		})
+	}))
+	defer server.Close()
+
+	clientConfig := openai.DefaultConfig("test")
+	clientConfig.BaseURL = server.URL
+	s := &Service{
+		openaiClient: openai.NewClientWithConfig(clientConfig),
+		config:       ServiceConfig{Backend: BackendOpenAI, Model: "gpt-4o", NCandidates: 3, UseJSONSchema: true},
+		logger:       zerolog.Nop(),
+	}
+
+	ctx := context.Background()
+	if _, err := s.send(ctx, "Describe the tone", 1, 0); err != nil {
+		t.Fatalf("plain request error = %v", err)
+	}
+	translationCtx := context.WithValue(ctx, translationRequestKey{}, true)
+	if _, err := s.send(translationCtx, "Translate", 1, 0); err != nil {
+		t.Fatalf("translation request error = %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if _, ok := requests[0]["response_format"]; ok {
+		t.Error("plain request has a response_format")
+	}
+	if _, ok := requests[0]["n"]; ok {
+		t.Errorf("plain request asks for n = %s", requests[0]["n"])
+	}
+	if _, ok := requests[1]["response_format"]; !ok {
+		t.Error("translation request has no response_format")
+	}
+	if got := string(requests[1]["n"]); got != "3" {
+		t.Errorf("translation request asks for n = %s, want 3", got)
+	}
+}
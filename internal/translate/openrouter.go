@@ -4,6 +4,7 @@
 package translate
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,6 +15,115 @@ import (
 	openai "github.com/sashabaranov/go-openai"
 )
 
+// DefaultOpenRouterBaseURL is the OpenRouter API endpoint
+const DefaultOpenRouterBaseURL = "https://openrouter.ai/api/v1"
+
+const (
+	defaultOpenRouterSiteURL = "https://github.com/21d5/SRTran"
+	defaultOpenRouterAppName = "SRTran"
+)
+
+// openRouterTransport adds the OpenRouter attribution headers to every
+// request and the provider preferences and transforms to request bodies,
+// which go-openai has no fields for
+type openRouterTransport struct {
+	base          http.RoundTripper
+	siteURL       string
+	appName       string
+	providerOrder []string
+	transforms    []string
+}
+
+// RoundTrip implements http.RoundTripper. Headers and body are set on a
+// clone, as a RoundTripper must not modify the caller's request.
+func (t *openRouterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.siteURL != "" {
+		req.Header.Set("HTTP-Referer", t.siteURL)
+	}
+	if t.appName != "" {
+		req.Header.Set("X-Title", t.appName)
+	}
+	if fields := t.bodyFields(); len(fields) > 0 && req.Body != nil && req.Method == http.MethodPost {
+		if err := setBodyFields(req, fields); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// bodyFields returns the fields added to request bodies: "provider":
+// {"order": [...]} and "transforms": [...]. Transforms "none" is sent as
+// an empty list, which disables OpenRouter's default transforms.
+func (t *openRouterTransport) bodyFields() map[string]interface{} {
+	fields := make(map[string]interface{})
+	if len(t.providerOrder) > 0 {
+		fields["provider"] = map[string][]string{"order": t.providerOrder}
+	}
+	if len(t.transforms) > 0 {
+		transforms := t.transforms
+		if len(transforms) == 1 && transforms[0] == "none" {
+			transforms = []string{}
+		}
+		fields["transforms"] = transforms
+	}
+	return fields
+}
+
+// setBodyFields sets fields in the JSON body of req
+func setBodyFields(req *http.Request, fields map[string]interface{}) error {
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Errorf("failed to decode request body: %w", err)
+	}
+	for name, value := range fields {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		payload[name] = encoded
+	}
+
+	body, err = json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return nil
+}
+
+// newOpenRouterHTTPClient creates an HTTP client that sets the OpenRouter attribution headers
+func newOpenRouterHTTPClient(config ServiceConfig) *http.Client {
+	siteURL := config.OpenRouterSiteURL
+	if siteURL == "" {
+		siteURL = defaultOpenRouterSiteURL
+	}
+	appName := config.OpenRouterAppName
+	if appName == "" {
+		appName = defaultOpenRouterAppName
+	}
+
+	return &http.Client{
+		Transport: &openRouterTransport{
+			base:          http.DefaultTransport,
+			siteURL:       siteURL,
+			appName:       appName,
+			providerOrder: config.OpenRouterProviderOrder,
+			transforms:    config.OpenRouterTransforms,
+		},
+	}
+}
+
 // OpenRouterKeyInfo represents the response from the OpenRouter key info endpoint
 type OpenRouterKeyInfo struct {
 	Data struct {
@@ -51,12 +161,12 @@ type ProviderErrorMetadata struct {
 	Raw          interface{} `json:"raw"`
 }
 
-func (s *Service) translateWithOpenRouter(ctx context.Context, text string, sourceLang, targetLang string) ([][]string, error) {
-	if s.config.Model == "" {
+func (s *Service) translateWithOpenRouter(ctx context.Context, prompt string, expectedCount int, temperature float32) ([][]string, error) {
+	if s.requestModel(ctx) == "" {
 		return nil, fmt.Errorf("model must be specified for OpenRouter backend")
 	}
 
-	maxRetries := 10
+	maxRetries := s.maxAttempts(10)
 	var lastErr error
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
@@ -82,11 +192,12 @@ func (s *Service) translateWithOpenRouter(ctx context.Context, text string, sour
 		resp, err := s.openaiClient.CreateChatCompletion(
 			ctx,
 			openai.ChatCompletionRequest{
-				Model: s.config.Model,
+				Model:       s.requestModel(ctx),
+				Temperature: temperature,
 				Messages: []openai.ChatCompletionMessage{
 					{
 						Role:    openai.ChatMessageRoleSystem,
-						Content: fmt.Sprintf(translationPrompt, sourceLang, targetLang, text),
+						Content: prompt,
 					},
 				},
 			},
@@ -127,6 +238,8 @@ func (s *Service) translateWithOpenRouter(ctx context.Context, text string, sour
 			return nil, lastErr
 		}
 
+		s.observeRateLimit(resp.GetRateLimitHeaders())
+
 		// Validate response
 		if len(resp.Choices) == 0 {
 			lastErr = fmt.Errorf("empty response from OpenRouter")
@@ -154,9 +267,6 @@ func (s *Service) translateWithOpenRouter(ctx context.Context, text string, sour
 		// Split response by subtitle separator
 		translations := strings.Split(resp.Choices[0].Message.Content, "===SUBTITLE===")
 
-		// Count expected translations by counting "[N]" markers in input
-		expectedCount := strings.Count(text, "[")
-
 		var cleanTranslations [][]string
 		for _, t := range translations {
 			t = strings.TrimSpace(t)
@@ -165,9 +275,7 @@ func (s *Service) translateWithOpenRouter(ctx context.Context, text string, sour
 			}
 
 			// Remove the [N] prefix
-			if idx := strings.Index(t, "]\n"); idx != -1 {
-				t = strings.TrimSpace(t[idx+2:])
-			}
+			t = stripMarker(ctx, t)
 
 			// Split by natural line breaks
 			lines := strings.Split(t, "\n")
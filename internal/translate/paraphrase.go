@@ -0,0 +1,85 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// paraphrasePrompt asks the model to rewrite translated subtitles so they
+// read naturally, using the block format of translationPrompt
+const paraphrasePrompt = `Rephrase these subtitles to sound natural in %s, keeping the same meaning and line structure.
+Never split or merge subtitle blocks and keep placeholder markers like [%%1] unchanged.
+
+Format:
+[N] (subtitle number)
+Rephrased text (same line breaks)
+===SUBTITLE=== separator between blocks
+
+Here are the subtitles to rephrase:
+
+%s`
+
+// modelKey is the context key of a model that overrides the current one
+// for the requests made with that context
+type modelKey struct{}
+
+// requestModel returns the model to use for a request made with ctx
+func (s *Service) requestModel(ctx context.Context) string {
+	if model, ok := ctx.Value(modelKey{}).(string); ok && model != "" {
+		return model
+	}
+	return s.currentModel()
+}
+
+// paraphrase sends the translations through a second pass that rephrases
+// them to sound natural in targetLang, using TwoPassModel when set.
+// Subtitles without a translation are skipped and batches that fail keep
+// their first-pass translation.
+func (s *Service) paraphrase(ctx context.Context, subtitles []srt.Subtitle, targetLang string) {
+	if s.config.TwoPassModel != "" {
+		ctx = context.WithValue(ctx, modelKey{}, s.config.TwoPassModel)
+	}
+	prompt := func(text string, count int) (string, error) {
+		return fmt.Sprintf(paraphrasePrompt, targetLang, text), nil
+	}
+
+	// the batch code sends Text, so send the translations in its place
+	var pending []srt.Subtitle
+	var indexes []int
+	for i, sub := range subtitles {
+		if len(sub.Translated) == 0 || s.isTimedOutText(sub.Translated) {
+			continue
+		}
+		sub.Text = sub.Translated
+		pending = append(pending, sub)
+		indexes = append(indexes, i)
+	}
+
+	s.logger.Info().Int("subtitles", len(pending)).Msg("paraphrasing translations")
+
+	for start := 0; start < len(pending); {
+		end := s.batchEnd(pending, start)
+		batch := pending[start:end]
+		rephrased, err := s.translateBatch(ctx, batch, start, prompt)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Warn().
+				Err(err).
+				Int("first_index", batch[0].Index).
+				Int("last_index", batch[len(batch)-1].Index).
+				Msg("paraphrasing failed, keeping the first-pass translation")
+		} else {
+			for j, sub := range rephrased {
+				subtitles[indexes[start+j]].Translated = sub.Translated
+			}
+		}
+		start = end
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranslationPromptVerbs(t *testing.T) {
+	// source language, target language, numbered rules, the response
+	// format and the batch text, in the order buildPrompt passes them
+	if got := strings.Count(translationPrompt, "%s"); got != 5 {
+		t.Fatalf("translationPrompt has %d %%s verbs, want 5", got)
+	}
+
+	prompt := buildPrompt("SOURCE_SENTINEL", "TARGET_SENTINEL", "TEXT_SENTINEL", EffectsTranslated, blocksFormat, "RULE_SENTINEL")
+	for _, sentinel := range []string{"SOURCE_SENTINEL", "TARGET_SENTINEL", "TEXT_SENTINEL", "RULE_SENTINEL"} {
+		if got := strings.Count(prompt, sentinel); got != 1 {
+			t.Errorf("prompt contains %s %d times, want 1", sentinel, got)
+		}
+	}
+	if strings.Contains(prompt, "%!") {
+		t.Errorf("prompt has a formatting error:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "===SUBTITLE=== separator between blocks") {
+		t.Errorf("prompt does not ask for the ===SUBTITLE=== separator:\n%s", prompt)
+	}
+	if !strings.HasSuffix(prompt, "\n\nTEXT_SENTINEL") {
+		t.Errorf("prompt does not end with the batch text:\n%s", prompt)
+	}
+}
+
+func TestBuildPromptJSONFormat(t *testing.T) {
+	prompt := buildPrompt("english", "german", "[1]\nHello", EffectsTranslated, jsonFormat)
+	if strings.Contains(prompt, "separator between blocks") {
+		t.Errorf("JSON prompt asks for the ===SUBTITLE=== separator:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "Format:\n"+jsonFormat+"\n") {
+		t.Errorf("JSON prompt does not describe the JSON format:\n%s", prompt)
+	}
+}
+
+func TestBuildPromptEffects(t *testing.T) {
+	tests := []struct {
+		name    string
+		effects EffectsMode
+		want    string
+	}{
+		{name: "translated", effects: EffectsTranslated, want: effectsRules[EffectsTranslated]},
+		{name: "original", effects: EffectsOriginal, want: effectsRules[EffectsOriginal]},
+		{name: "empty uses translated", effects: "", want: effectsRules[EffectsTranslated]},
+		{name: "unknown uses translated", effects: "bogus", want: effectsRules[EffectsTranslated]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt := buildPrompt("english", "german", "[1]\nHello", tt.effects, blocksFormat)
+			if !strings.Contains(prompt, "\n2. "+tt.want+"\n") {
+				t.Errorf("prompt does not use rule 2 %q:\n%s", tt.want, prompt)
+			}
+		})
+	}
+}
+
+func TestBuildPromptExtraRules(t *testing.T) {
+	prompt := buildPrompt("english", "german", "[1]\nHello", EffectsTranslated, blocksFormat, "First extra rule", "Second extra rule")
+
+	want := "10. Use contractions where natural for spoken language\n11. First extra rule\n12. Second extra rule\n"
+	if !strings.Contains(prompt, want) {
+		t.Errorf("prompt does not contain %q:\n%s", want, prompt)
+	}
+
+	if !strings.Contains(prompt, "\n9. Keep placeholder markers like [%1] unchanged\n") {
+		t.Errorf("prompt does not keep the placeholder rule:\n%s", prompt)
+	}
+
+	without := buildPrompt("english", "german", "[1]\nHello", EffectsTranslated, blocksFormat)
+	if strings.Contains(without, "\n11. ") {
+		t.Errorf("prompt without extra rules has rule 11:\n%s", without)
+	}
+}
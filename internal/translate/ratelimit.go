@@ -0,0 +1,119 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Rate limit strategies for ServiceConfig.RateLimitStrategy
+const (
+	// RateLimitFixed refills the bucket at the configured RPM
+	RateLimitFixed = "fixed"
+	// RateLimitAdaptive adjusts the refill rate to the remaining requests
+	// reported by the API
+	RateLimitAdaptive = "adaptive"
+)
+
+// adaptive rate limiting thresholds, as fractions of the request limit
+// remaining, and bounds on the rate as multiples of the configured RPM
+const (
+	adaptiveSpeedUpAbove  = 0.5
+	adaptiveSlowDownBelow = 0.2
+	adaptiveMaxFactor     = 2.0
+	adaptiveMinFactor     = 0.25
+)
+
+// validateRateLimitStrategy returns an error for unknown strategies
+func validateRateLimitStrategy(strategy string) error {
+	switch strategy {
+	case RateLimitFixed, RateLimitAdaptive:
+		return nil
+	default:
+		return fmt.Errorf("unknown rate limit strategy %q (want fixed or adaptive)", strategy)
+	}
+}
+
+// tokenBucket is a token-bucket rate limiter. It refills at a steady rate and
+// allows bursts of up to capacity requests when it has been idle.
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	// refill rate in tokens per second
+	rate float64
+	// baseRate is the configured rate that adaptive changes are bounded by
+	baseRate float64
+	last     time.Time
+}
+
+// newTokenBucket creates a full bucket refilling at rpm tokens per minute
+func newTokenBucket(rpm, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = rpm
+	}
+	return &tokenBucket{
+		tokens:   float64(burst),
+		capacity: float64(burst),
+		rate:     float64(rpm) / 60,
+		baseRate: float64(rpm) / 60,
+		last:     time.Now(),
+	}
+}
+
+// refill adds the tokens accumulated since the last call; mu must be held
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+}
+
+// Wait blocks until a token is available or ctx is done
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		b.refill(time.Now())
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Adapt changes the refill rate based on the requests remaining in the
+// API's current window: faster when more than half is left, slower when
+// less than a fifth is. It returns the new rate in requests per minute.
+func (b *tokenBucket) Adapt(remaining, limit int) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if limit <= 0 {
+		return b.rate * 60
+	}
+
+	b.refill(time.Now())
+	switch left := float64(remaining) / float64(limit); {
+	case left > adaptiveSpeedUpAbove:
+		b.rate = min(b.rate*1.25, b.baseRate*adaptiveMaxFactor)
+	case left < adaptiveSlowDownBelow:
+		b.rate = max(b.rate/2, b.baseRate*adaptiveMinFactor)
+	}
+	return b.rate * 60
+}
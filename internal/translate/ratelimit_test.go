@@ -0,0 +1,143 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketBurst(t *testing.T) {
+	tests := []struct {
+		name  string
+		rpm   int
+		burst int
+		want  int
+	}{
+		{name: "burst", rpm: 60, burst: 3, want: 3},
+		{name: "burst defaults to rpm", rpm: 5, burst: 0, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTokenBucket(tt.rpm, tt.burst)
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			for i := range tt.want {
+				if err := b.Wait(ctx); err != nil {
+					t.Fatalf("Wait() %d of the burst error = %v", i+1, err)
+				}
+			}
+			// at these rates the next token is seconds away
+			if err := b.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Wait() after the burst error = %v, want %v", err, context.DeadlineExceeded)
+			}
+		})
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokens  float64
+		elapsed time.Duration
+		want    float64
+	}{
+		{name: "empty", tokens: 0, elapsed: 0, want: 0},
+		{name: "one second", tokens: 0, elapsed: time.Second, want: 2},
+		{name: "partial", tokens: 0.5, elapsed: 250 * time.Millisecond, want: 1},
+		{name: "capped at capacity", tokens: 1, elapsed: time.Minute, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 120 rpm refills 2 tokens per second
+			b := newTokenBucket(120, 4)
+			b.tokens = tt.tokens
+			now := time.Now()
+			b.last = now.Add(-tt.elapsed)
+
+			b.refill(now)
+			if b.tokens != tt.want {
+				t.Errorf("tokens = %v, want %v", b.tokens, tt.want)
+			}
+			if !b.last.Equal(now) {
+				t.Errorf("last = %v, want %v", b.last, now)
+			}
+		})
+	}
+}
+
+func TestTokenBucketWaitsForRefill(t *testing.T) {
+	// 600 rpm refills a token every 100ms
+	b := newTokenBucket(600, 1)
+	ctx := context.Background()
+	if err := b.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := b.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Wait() returned after %v, want about 100ms", elapsed)
+	}
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	b := newTokenBucket(1, 1)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	if err := b.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait() returned %v after cancellation, want at once", elapsed)
+	}
+}
+
+func TestTokenBucketAdapt(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining int
+		limit     int
+		calls     int
+		want      float64
+	}{
+		{name: "speeds up", remaining: 80, limit: 100, calls: 1, want: 75},
+		{name: "capped at twice the rpm", remaining: 80, limit: 100, calls: 10, want: 120},
+		{name: "slows down", remaining: 10, limit: 100, calls: 1, want: 30},
+		{name: "floored at a quarter of the rpm", remaining: 10, limit: 100, calls: 10, want: 15},
+		{name: "unchanged in between", remaining: 30, limit: 100, calls: 1, want: 60},
+		{name: "unknown limit", remaining: 0, limit: 0, calls: 1, want: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTokenBucket(60, 0)
+			var got float64
+			for range tt.calls {
+				got = b.Adapt(tt.remaining, tt.limit)
+			}
+			if got != tt.want {
+				t.Errorf("Adapt() = %v, want %v", got, tt.want)
+			}
+			if b.rate*60 != tt.want {
+				t.Errorf("rate = %v rpm, want %v", b.rate*60, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// repairPrompt asks the model to fix OCR errors without translating
+const repairPrompt = `You are a subtitle proofreader. The following subtitles%s were produced by OCR and contain recognition errors. Fix them following these rules:
+1. Do NOT translate; keep the original language
+2. Fix misrecognized characters (e.g., "l" read as "I", "0" as "O", "rn" as "m")
+3. Rejoin words split by stray spaces and separate words that were run together
+4. Fix obviously wrong punctuation and capitalization
+5. Do not rephrase, shorten or add text beyond fixing errors
+6. Maintain original line breaks and formatting symbols (e.g., <i>, [music])
+7. Never split or merge subtitle blocks
+
+Format:
+[N] (subtitle number)
+Corrected text (same line breaks)
+===SUBTITLE=== separator between blocks
+
+Here are the subtitles to correct:
+
+%s`
+
+// buildRepairPrompt fills in repairPrompt; language may be empty
+func buildRepairPrompt(language, text string) string {
+	if language != "" {
+		language = fmt.Sprintf(" (in %s)", language)
+	}
+	return fmt.Sprintf(repairPrompt, language, text)
+}
+
+// Repair asks the model to fix OCR errors in subtitles without translating
+// them. The corrected text is stored in Translated.
+func (s *Service) Repair(ctx context.Context, subtitles []srt.Subtitle, language string) ([]srt.Subtitle, error) {
+	s.logger.Debug().
+		Int("total_subtitles", len(subtitles)).
+		Str("language", language).
+		Msg("starting OCR repair")
+
+	return s.processBatches(ctx, subtitles, func(text string, count int) (string, error) {
+		return buildRepairPrompt(language, text), nil
+	})
+}
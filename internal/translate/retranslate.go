@@ -0,0 +1,78 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// retranslateTemperature is used when retranslating unchanged subtitles:
+// 0.2 above the 1.0 default of the supported providers
+const retranslateTemperature = 1.2
+
+// retranslateUnchanged retranslates each subtitle whose translation is more
+// similar to its original text than RetranslateThreshold, which happens when
+// a model echoes the source language back. Subtitles that still fail are kept.
+func (s *Service) retranslateUnchanged(ctx context.Context, subtitles []srt.Subtitle, prompt promptFunc) {
+	for i, sub := range subtitles {
+		ratio := Similarity(strings.Join(sub.Text, "\n"), strings.Join(sub.Translated, "\n"))
+		if ratio <= s.config.RetranslateThreshold {
+			continue
+		}
+
+		s.logger.Warn().
+			Int("index", sub.Index).
+			Float64("similarity", ratio).
+			Msg("translation is nearly identical to the original, retranslating")
+
+		retried, err := s.translateBatchInternal(ctx, subtitles[i:i+1], i, prompt, retranslateTemperature)
+		if err != nil {
+			s.logger.Warn().
+				Int("index", sub.Index).
+				Err(err).
+				Msg("retranslation failed, keeping the original translation")
+			continue
+		}
+		subtitles[i] = retried[0]
+	}
+}
+
+// Similarity returns the normalized Levenshtein similarity of a and b,
+// from 0 (completely different) to 1 (identical), ignoring case
+func Similarity(a, b string) float64 {
+	ra := []rune(strings.Map(unicode.ToLower, a))
+	rb := []rune(strings.Map(unicode.ToLower, b))
+
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -5,11 +5,14 @@ package translate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -25,52 +28,157 @@ type Service struct {
 	config       ServiceConfig
 	verbose      bool
 	logger       zerolog.Logger
-	// rate limiter fields
-	rateLimiter   *time.Ticker
-	rateLimiterMu sync.Mutex
+	// rateLimiter is nil when no RPM limit is configured
+	rateLimiter *tokenBucket
+	// modelMu guards currentModelIndex, which is shared by concurrent Translate calls
+	modelMu sync.Mutex
+	// index into config.ModelFallback of the model currently in use
+	currentModelIndex int
+	// promptTemplate is nil when the built-in prompt is used
+	promptTemplate *template.Template
+	// safetySettings is sent with every Google AI and Vertex AI request
+	safetySettings []*genai.SafetySetting
+	// stats counts batches and retries for Stats
+	stats statsCounter
 }
 
-// batch size for translations
-const defaultBatchSize = 20
+// DefaultBatchSize is the number of subtitles sent per request
+// when ServiceConfig.BatchSize is not set
+const DefaultBatchSize = 20
 
 // NewService creates a new translation service
 func NewService(config ServiceConfig) (*Service, error) {
-	// API key is required for all backends except LM Studio
-	if config.APIKey == "" && config.Backend != BackendLMStudio {
+	// API key is required for all backends except LM Studio and Vertex AI,
+	// which uses Google Cloud application default credentials
+	if config.APIKey == "" && config.Backend != BackendLMStudio && config.Backend != BackendVertexAI {
 		return nil, fmt.Errorf("API key is required for %s backend", config.Backend)
 	}
 
+	if config.UseJSONSchema && config.Backend != BackendOpenAI {
+		return nil, fmt.Errorf("JSON schema responses are only supported by the openai backend")
+	}
+
+	if config.ShuffleValidate && config.UseJSONSchema {
+		return nil, fmt.Errorf("shuffle validation cannot be used with JSON schema responses")
+	}
+
+	if config.SourceContextLines < 0 {
+		return nil, fmt.Errorf("source context lines must not be negative")
+	}
+
+	if config.BatchSize < 0 {
+		return nil, fmt.Errorf("batch size must not be negative")
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+
+	switch config.Effects {
+	case "":
+		config.Effects = EffectsTranslated
+	case EffectsTranslated, EffectsOriginal:
+	default:
+		return nil, fmt.Errorf("unknown effects mode %q (want translated or original)", config.Effects)
+	}
+
+	if config.RateLimitStrategy == "" {
+		config.RateLimitStrategy = RateLimitFixed
+	}
+	if err := validateRateLimitStrategy(config.RateLimitStrategy); err != nil {
+		return nil, err
+	}
+	if config.LineLimitMode == "" {
+		config.LineLimitMode = LineLimitWarn
+	}
+	if err := validateLineLimitMode(config.LineLimitMode); err != nil {
+		return nil, err
+	}
+
+	if config.NCandidates < 0 {
+		return nil, fmt.Errorf("number of candidates must not be negative")
+	}
+
+	if config.RequestTimeout < 0 {
+		return nil, fmt.Errorf("request timeout must not be negative")
+	}
+
+	if config.MaxTextLinesPerSubtitle < 0 {
+		return nil, fmt.Errorf("maximum text lines per subtitle must not be negative")
+	}
+
+	if config.TokenizerModel == "" {
+		config.TokenizerModel = DefaultTokenizerModel
+	}
+	if err := validateTokenizerModel(config.TokenizerModel); err != nil {
+		return nil, err
+	}
+
+	if config.SafetyThreshold == "" {
+		config.SafetyThreshold = DefaultSafetyThreshold
+	}
+	safety, err := safetySettings(config.SafetyThreshold)
+	if err != nil {
+		return nil, err
+	}
+
 	service := &Service{
-		config:  config,
-		verbose: config.Verbose,
-		logger:  zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger(),
+		config:         config,
+		verbose:        config.Verbose,
+		safetySettings: safety,
+	}
+
+	if config.PromptTemplate != "" {
+		tmpl, err := template.New("prompt").Parse(config.PromptTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse prompt template: %w", err)
+		}
+		service.promptTemplate = tmpl
+	}
+
+	if config.Logger != nil {
+		service.logger = *config.Logger
+	} else {
+		service.logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
+	}
+
+	if config.Grounding {
+		if config.Backend != BackendGoogleAI && config.Backend != BackendVertexAI {
+			return nil, fmt.Errorf("grounding is only supported by the googleai and vertexai backends")
+		}
+		service.logger.Warn().Msg("Google Search grounding is billed per request and is not available on the free tier")
 	}
 
 	// initialize rate limiter if RPM is set
 	if config.RPM > 0 {
-		// calculate interval between requests
-		interval := time.Minute / time.Duration(config.RPM)
-		service.rateLimiter = time.NewTicker(interval)
+		service.rateLimiter = newTokenBucket(config.RPM, config.BurstSize)
 		service.logger.Info().
 			Int("rpm", config.RPM).
-			Dur("interval", interval).
+			Int("burst", int(service.rateLimiter.capacity)).
 			Msg("rate limiter initialized")
 	}
 
 	switch config.Backend {
 	case BackendOpenAI:
 		clientConfig := openai.DefaultConfig(config.APIKey)
+		clientConfig.OrgID = config.Organization
+		if config.BaseURL != "" {
+			clientConfig.BaseURL = config.BaseURL
+		}
 		service.openaiClient = openai.NewClientWithConfig(clientConfig)
 	case BackendOpenRouter:
 		clientConfig := openai.DefaultConfig(config.APIKey)
 		clientConfig.BaseURL = config.BaseURL
+		clientConfig.HTTPClient = newOpenRouterHTTPClient(config)
 		service.openaiClient = openai.NewClientWithConfig(clientConfig)
 	case BackendLMStudio:
 		if config.BaseURL == "" {
-			config.BaseURL = "http://localhost:1234/v1"
+			config.BaseURL = DefaultLMStudioBaseURL
 		}
 		clientConfig := openai.DefaultConfig("") // Empty API key is fine for LM Studio
 		clientConfig.BaseURL = config.BaseURL
+		if client := newLMStudioHTTPClient(config); client != nil {
+			clientConfig.HTTPClient = client
+		}
 		service.openaiClient = openai.NewClientWithConfig(clientConfig)
 	case BackendGoogleAI:
 		client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
@@ -81,6 +189,22 @@ func NewService(config ServiceConfig) (*Service, error) {
 			return nil, fmt.Errorf("failed to create Google AI client: %w", err)
 		}
 		service.googleClient = client
+	case BackendVertexAI:
+		if config.ProjectID == "" {
+			return nil, fmt.Errorf("project ID is required for %s backend", config.Backend)
+		}
+		if config.Location == "" {
+			config.Location = defaultVertexAILocation
+		}
+		client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+			Backend:  genai.BackendVertexAI,
+			Project:  config.ProjectID,
+			Location: config.Location,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Vertex AI client: %w", err)
+		}
+		service.googleClient = client
 	default:
 		return nil, fmt.Errorf("unsupported backend: %s", config.Backend)
 	}
@@ -88,33 +212,148 @@ func NewService(config ServiceConfig) (*Service, error) {
 	return service, nil
 }
 
+// buildPrompt renders the prompt for a batch and passes it through
+// RewritePrompt when set. tone is the tone detected for the file being
+// translated, or "" for none.
+func (s *Service) buildPrompt(sourceLang, targetLang, tone, text string, count int) (string, error) {
+	prompt, err := s.renderPrompt(sourceLang, targetLang, tone, text, count)
+	if err != nil || s.config.RewritePrompt == nil {
+		return prompt, err
+	}
+	return s.config.RewritePrompt(sourceLang, targetLang, prompt)
+}
+
+// renderPrompt renders the custom prompt template if one is configured,
+// otherwise the built-in translationPrompt
+func (s *Service) renderPrompt(sourceLang, targetLang, tone, text string, count int) (string, error) {
+	if s.promptTemplate == nil {
+		var rules []string
+		if rule := s.toneRule(tone); rule != "" {
+			rules = append(rules, rule)
+		}
+		if s.config.Glossary != "" {
+			rules = append(rules, "Translate these terms consistently as given: "+s.config.Glossary)
+		}
+		if s.config.TempByLength {
+			rules = append(rules, temperatureRule)
+		}
+		format := blocksFormat
+		if s.config.UseJSONSchema {
+			format = jsonFormat
+		}
+		return buildPrompt(sourceLang, targetLang, text, s.config.Effects, format, rules...), nil
+	}
+
+	var prompt strings.Builder
+	err := s.promptTemplate.Execute(&prompt, promptData{
+		SourceLang:    sourceLang,
+		TargetLang:    targetLang,
+		BatchText:     text,
+		SubtitleCount: count,
+		Glossary:      s.config.Glossary,
+		Tone:          tone,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+	return prompt.String(), nil
+}
+
 // waitForRateLimit waits for the rate limiter if it's configured
 func (s *Service) waitForRateLimit(ctx context.Context) error {
 	if s.rateLimiter == nil {
 		return nil
 	}
 
-	s.rateLimiterMu.Lock()
-	defer s.rateLimiterMu.Unlock()
+	return s.rateLimiter.Wait(ctx)
+}
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-s.rateLimiter.C:
-		return nil
+// observeRateLimit adapts the rate limiter to the rate limit headers of
+// a successful response when the adaptive strategy is used
+func (s *Service) observeRateLimit(headers openai.RateLimitHeaders) {
+	if s.rateLimiter == nil || s.config.RateLimitStrategy != RateLimitAdaptive {
+		return
+	}
+	rpm := s.rateLimiter.Adapt(headers.RemainingRequests, headers.LimitRequests)
+	if s.verbose && headers.LimitRequests > 0 {
+		s.logger.Debug().
+			Int("remaining", headers.RemainingRequests).
+			Int("limit", headers.LimitRequests).
+			Float64("rpm", rpm).
+			Msg("adapted rate limit")
 	}
 }
 
-// Close cleans up resources used by the service
-func (s *Service) Close() {
-	if s.rateLimiter != nil {
-		s.rateLimiter.Stop()
+// CurrentModel returns the model currently in use, which changes when
+// a model in ModelFallback fails
+func (s *Service) CurrentModel() string {
+	return s.currentModel()
+}
+
+// currentModel returns the model currently in use
+func (s *Service) currentModel() string {
+	s.modelMu.Lock()
+	defer s.modelMu.Unlock()
+	return s.currentModelLocked()
+}
+
+// currentModelLocked is currentModel for callers holding modelMu
+func (s *Service) currentModelLocked() string {
+	if len(s.config.ModelFallback) > 0 {
+		return s.config.ModelFallback[s.currentModelIndex]
+	}
+	return s.config.Model
+}
+
+// advanceModel switches to the next model in the fallback chain.
+// It returns false if the chain is exhausted.
+func (s *Service) advanceModel(err error) bool {
+	s.modelMu.Lock()
+	defer s.modelMu.Unlock()
+
+	if s.currentModelIndex+1 >= len(s.config.ModelFallback) {
+		return false
+	}
+
+	previous := s.currentModelLocked()
+	s.currentModelIndex++
+
+	s.logger.Warn().
+		Err(err).
+		Str("from", previous).
+		Str("to", s.currentModelLocked()).
+		Msg("switching to fallback model")
+	return true
+}
+
+// isQuotaOrAuthError reports whether err indicates exhausted quota or rejected credentials
+func isQuotaOrAuthError(err error) bool {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.HTTPStatusCode {
+		case 401, 402, 403:
+			return true
+		}
+		if code, ok := apiErr.Code.(string); ok && code == "insufficient_quota" {
+			return true
+		}
 	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "quota") ||
+		strings.Contains(msg, "insufficient credits") ||
+		strings.Contains(msg, "permission denied") ||
+		strings.Contains(msg, "error code: 401") ||
+		strings.Contains(msg, "error code: 402")
 }
 
-// translateBatch translates a batch of subtitles
-func (s *Service) translateBatch(ctx context.Context, subtitles []srt.Subtitle, sourceLang, targetLang string) ([]srt.Subtitle, error) {
-	batchSize := 20 // or whatever the current batch size is
+// Close cleans up resources used by the service
+func (s *Service) Close() {}
+
+// translateBatch translates a batch of subtitles.
+// offset is the position of the batch in the full subtitle slice.
+func (s *Service) translateBatch(ctx context.Context, subtitles []srt.Subtitle, offset int, prompt promptFunc) ([]srt.Subtitle, error) {
+	batchSize := s.config.BatchSize
 	var translated []srt.Subtitle
 
 	for i := 0; i < len(subtitles); i += batchSize {
@@ -129,86 +368,109 @@ func (s *Service) translateBatch(ctx context.Context, subtitles []srt.Subtitle,
 		maxAttempts := 10
 		baseDelay := time.Second
 
+		var batchTranslated []srt.Subtitle
+		var err error
 		for attempt := 0; attempt < maxAttempts; attempt++ {
 			// Wait for rate limiter
 			if err := s.waitForRateLimit(ctx); err != nil {
-				return nil, fmt.Errorf("rate limit wait error: %w", err)
+				return nil, s.newTranslationError(offset+i, offset+end, attempt+1, fmt.Errorf("rate limit wait error: %w", err))
 			}
 
-			batchTranslated, err := s.translateBatchInternal(ctx, batch, sourceLang, targetLang)
-			if err != nil {
-				if strings.Contains(err.Error(), "429") ||
-					strings.Contains(err.Error(), "RESOURCE_EXHAUSTED") {
-					delay := baseDelay * time.Duration(math.Pow(2, float64(attempt)))
-					s.logger.Warn().
-						Int("attempt", attempt).
-						Dur("backoff", delay).
-						Msg("rate limit hit, backing off")
-
-					select {
-					case <-ctx.Done():
-						return nil, ctx.Err()
-					case <-time.After(delay):
-						continue // retry after delay
-					}
-				}
-				return nil, fmt.Errorf("failed to translate batch %d-%d: %w", i, end, err)
+			batchTranslated, err = s.translateBatchInternal(ctx, batch, offset+i, prompt, 0)
+			rateLimited := err != nil && (strings.Contains(err.Error(), "429") ||
+				strings.Contains(err.Error(), "RESOURCE_EXHAUSTED"))
+			if !rateLimited || s.config.FailFast || s.config.NoRetry || attempt == maxAttempts-1 {
+				break
 			}
 
-			translated = append(translated, batchTranslated...)
-
-			break // successful translation, move to next batch
+			delay := baseDelay * time.Duration(math.Pow(2, float64(attempt)))
+			s.logger.Warn().
+				Int("attempt", attempt).
+				Dur("backoff", delay).
+				Msg("rate limit hit, backing off")
+			s.stats.addRetry()
+
+			select {
+			case <-ctx.Done():
+				return nil, s.newTranslationError(offset+i, offset+end, attempt+1, ctx.Err())
+			case <-time.After(delay):
+			}
+		}
+		// translateBatchInternal only returns *TranslationError
+		if err != nil {
+			return nil, err
 		}
+
+		translated = append(translated, batchTranslated...)
 	}
 
 	return translated, nil
 }
 
-// translateBatchInternal handles the actual translation of a batch of subtitles
-func (s *Service) translateBatchInternal(ctx context.Context, subtitles []srt.Subtitle, sourceLang, targetLang string) ([]srt.Subtitle, error) {
+// send sends prompt to the configured backend and returns the response split
+// into subtitle blocks; count is the number of blocks expected
+func (s *Service) send(ctx context.Context, prompt string, count int, temperature float32) ([][]string, error) {
+	switch s.config.Backend {
+	case BackendOpenAI:
+		return s.translateWithOpenAI(ctx, prompt, count, temperature)
+	case BackendOpenRouter:
+		return s.translateWithOpenRouter(ctx, prompt, count, temperature)
+	case BackendLMStudio:
+		return s.translateWithLMStudio(ctx, prompt, count, temperature)
+	case BackendGoogleAI, BackendVertexAI:
+		return s.translateWithGoogleAI(ctx, prompt, temperature)
+	default:
+		return nil, fmt.Errorf("unsupported backend: %s", s.config.Backend)
+	}
+}
+
+// translateBatchInternal handles the actual translation of a batch of subtitles.
+// A temperature of 0 uses the provider default.
+func (s *Service) translateBatchInternal(ctx context.Context, subtitles []srt.Subtitle, offset int, buildPrompt promptFunc, temperature float32) ([]srt.Subtitle, error) {
 	if len(subtitles) == 0 {
 		return subtitles, nil
 	}
 
-	maxRetries := 3
+	maxRetries := s.maxAttempts(4) - 1
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// combine subtitle texts with numbered markers
-		var batchText strings.Builder
-		for i, sub := range subtitles {
-			if i > 0 {
-				batchText.WriteString("\n===SUBTITLE===\n")
-			}
-			batchText.WriteString(fmt.Sprintf("[%d]\n", i+1))
-			batchText.WriteString(strings.Join(sub.Text, "\n"))
-			batchText.WriteString("\n")
+		sent, order := subtitles, []int(nil)
+		if s.config.ShuffleValidate {
+			sent, order = shuffleSubtitles(subtitles)
 		}
-
-		var cleanTranslations [][]string
-		var err error
-
-		switch s.config.Backend {
-		case BackendOpenAI:
-			cleanTranslations, err = s.translateWithOpenAI(ctx, batchText.String(), sourceLang, targetLang)
-		case BackendOpenRouter:
-			cleanTranslations, err = s.translateWithOpenRouter(ctx, batchText.String(), sourceLang, targetLang)
-		case BackendLMStudio:
-			cleanTranslations, err = s.translateWithLMStudio(ctx, batchText.String(), sourceLang, targetLang)
-		case BackendGoogleAI:
-			cleanTranslations, err = s.translateWithGoogleAI(ctx, batchText.String(), sourceLang, targetLang)
-		default:
-			return nil, fmt.Errorf("unsupported backend: %s", s.config.Backend)
+		prompt, err := buildPrompt(s.formatBatch(sent, order), len(sent))
+		if err != nil {
+			return nil, s.newTranslationError(offset, offset+len(subtitles), attempt+1, err)
 		}
 
+		reqCtx, cancel := ctx, context.CancelFunc(func() {})
+		if s.config.RequestTimeout > 0 {
+			reqCtx, cancel = context.WithTimeout(ctx, s.config.RequestTimeout)
+		}
+		if order != nil {
+			reqCtx = context.WithValue(reqCtx, keepMarkersKey{}, true)
+		}
+		model := s.requestModel(reqCtx)
+		cleanTranslations, err := s.sendCandidates(reqCtx, prompt, sent, temperature)
+		if err != nil && errors.Is(reqCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			err = fmt.Errorf("%w after %s: %v", ErrRequestTimeout, s.config.RequestTimeout, err)
+		}
+		cancel()
 		if err != nil {
+			// switch models without using up an attempt
+			if !s.config.NoRetry && isQuotaOrAuthError(err) && s.advanceModel(err) {
+				attempt--
+				continue
+			}
 			if attempt < maxRetries {
 				s.logger.Warn().
 					Int("attempt", attempt+1).
 					Int("max_retries", maxRetries).
 					Err(err).
 					Msg("translation attempt failed, retrying")
+				s.stats.addRetry()
 				continue
 			}
-			return nil, err
+			return nil, s.newTranslationError(offset, offset+len(subtitles), attempt+1, err)
 		}
 
 		// If we got fewer translations than expected but not zero
@@ -219,19 +481,35 @@ func (s *Service) translateBatchInternal(ctx context.Context, subtitles []srt.Su
 					Int("received", len(cleanTranslations)).
 					Int("attempt", attempt+1).
 					Msg("received partial translations, retrying")
+				s.stats.addRetry()
 				continue
 			}
 			// On final attempt, abort with error
-			return nil, fmt.Errorf("failed to get complete translations after %d attempts: expected %d, got %d",
-				maxRetries, len(subtitles), len(cleanTranslations))
+			translationErr := s.newTranslationError(offset, offset+len(subtitles), attempt+1,
+				fmt.Errorf("failed to get complete translations after %d attempts: expected %d, got %d",
+					maxRetries+1, len(subtitles), len(cleanTranslations)))
+			translationErr.SubtitleIndex = subtitles[len(cleanTranslations)].Index
+			return nil, translationErr
 		}
 
 		// Success case - we got the expected number of translations
 		if len(cleanTranslations) == len(subtitles) {
+			if order != nil {
+				restored, err := s.restoreOrder(cleanTranslations, order)
+				if err != nil {
+					if attempt < maxRetries {
+						s.stats.addRetry()
+						continue
+					}
+					return nil, s.newTranslationError(offset, offset+len(subtitles), attempt+1, err)
+				}
+				cleanTranslations = restored
+			}
 			result := make([]srt.Subtitle, len(subtitles))
 			copy(result, subtitles)
 			for i := range result {
 				result[i].Translated = cleanTranslations[i]
+				result[i].Metadata = withModel(result[i].Metadata, model)
 				if s.verbose {
 					s.logger.Debug().
 						Str("original", strings.Join(result[i].Text, "\n")).
@@ -243,7 +521,50 @@ func (s *Service) translateBatchInternal(ctx context.Context, subtitles []srt.Su
 		}
 	}
 
-	return nil, fmt.Errorf("failed to get complete translations after %d attempts", maxRetries)
+	return nil, s.newTranslationError(offset, offset+len(subtitles), maxRetries+1,
+		fmt.Errorf("failed to get complete translations after %d attempts", maxRetries+1))
+}
+
+// modelMetadataKey is the Subtitle.Metadata key holding the model that
+// translated the subtitle
+const modelMetadataKey = "model"
+
+// withModel returns a copy of metadata with the model that translated the
+// subtitle set
+func withModel(metadata map[string]interface{}, model string) map[string]interface{} {
+	result := make(map[string]interface{}, len(metadata)+1)
+	for k, v := range metadata {
+		result[k] = v
+	}
+	result[modelMetadataKey] = model
+	return result
+}
+
+// formatBatch combines subtitle texts with numbered markers. When order is
+// set, each marker uses the subtitle's position from order instead.
+func (s *Service) formatBatch(subtitles []srt.Subtitle, order []int) string {
+	var batchText strings.Builder
+	for i, sub := range subtitles {
+		if i > 0 {
+			batchText.WriteString("\n===SUBTITLE===\n")
+		}
+		pos := i
+		if order != nil {
+			pos = order[i]
+		}
+		batchText.WriteString(s.formatMarker(pos, sub) + "\n")
+		batchText.WriteString(strings.Join(sub.Text, "\n"))
+		batchText.WriteString("\n")
+	}
+	return batchText.String()
+}
+
+// maxAttempts returns n, or 1 when FailFast or NoRetry is set
+func (s *Service) maxAttempts(n int) int {
+	if s.config.FailFast || s.config.NoRetry {
+		return 1
+	}
+	return n
 }
 
 // rateLimitBackoff implements exponential backoff for rate limits
@@ -276,19 +597,181 @@ func (s *Service) Translate(ctx context.Context, subtitles []srt.Subtitle, sourc
 		Str("target_lang", targetLang).
 		Msg("starting batch translation")
 
+	if s.config.MaxTextLinesPerSubtitle > 0 {
+		subtitles = s.truncateText(subtitles)
+	}
+
+	// the tone is detected for every call, since one Service translates
+	// all the files of a directory run
+	var tone string
+	if s.config.ToneDetection && len(subtitles) > 0 {
+		detected, err := s.detectTone(ctx, subtitles)
+		if err != nil {
+			s.logger.Warn().Err(err).Msg("tone detection failed, using the default prompt")
+		} else {
+			tone = detected
+			s.logger.Info().Str("tone", tone).Msg("detected tone")
+		}
+	}
+
+	prompt := func(text string, count int) (string, error) {
+		return s.buildPrompt(sourceLang, targetLang, tone, text, count)
+	}
+
+	// paraphrasing below uses ctx, so it is not a translation request
+	batchCtx := context.WithValue(ctx, translationRequestKey{}, true)
+	result, err := s.processBatches(batchCtx, subtitles, prompt)
+	var partial *PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+
+	if s.config.RetranslateThreshold > 0 {
+		s.retranslateUnchanged(batchCtx, result, prompt)
+	}
+
+	if s.config.TempByLength {
+		s.logTemperatureCompliance(result)
+	}
+
+	if s.config.TwoPass {
+		s.paraphrase(ctx, result, targetLang)
+	}
+
+	if !strings.EqualFold(sourceLang, targetLang) {
+		for _, issue := range checkEffects(result, s.config.Effects) {
+			s.logger.Warn().
+				Int("index", issue.Index).
+				Str("effects", string(s.config.Effects)).
+				Str("reason", issue.Reason).
+				Msg("sound-effect annotation not in the expected language")
+		}
+	}
+
+	if s.config.MaxCharsPerLine > 0 {
+		s.applyLineLimit(result)
+	}
+
+	if partial != nil {
+		return result, partial
+	}
+	return result, nil
+}
+
+// singleTextPrompt asks the model to translate a short text such as a title
+const singleTextPrompt = `Translate this text from %s to %s. Reply with only the translation.
+
+%s`
+
+// TranslateSingleText translates a short text, such as a title, with a
+// single request outside the subtitle batches
+func (s *Service) TranslateSingleText(ctx context.Context, text, sourceLang, targetLang string) (string, error) {
+	response, err := s.send(ctx, fmt.Sprintf(singleTextPrompt, sourceLang, targetLang, text), 1, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to translate text: %w", err)
+	}
+	if len(response) == 0 || len(response[0]) == 0 {
+		return "", fmt.Errorf("empty translation response")
+	}
+	return strings.Join(response[0], " "), nil
+}
+
+// Prompt returns the prompt Translate would send for the batch numbered
+// batchIndex, counting from 0, without sending anything. Tone detection
+// needs a request, so the tone rule is left out.
+func (s *Service) Prompt(subtitles []srt.Subtitle, sourceLang, targetLang string, batchIndex int) (string, error) {
+	if s.config.MaxTextLinesPerSubtitle > 0 {
+		subtitles = s.truncateText(subtitles)
+	}
+
+	for n, start := 0, 0; start < len(subtitles); n++ {
+		end := s.batchEnd(subtitles, start)
+		if n == batchIndex {
+			batch := subtitles[start:end]
+			prompt := func(text string, count int) (string, error) {
+				return s.buildPrompt(sourceLang, targetLang, "", text, count)
+			}
+			return s.withSourceContext(prompt, subtitles, start, end)(s.formatBatch(batch, nil), len(batch))
+		}
+		start = end
+	}
+	return "", fmt.Errorf("batch index %d is out of range", batchIndex)
+}
+
+// truncateText returns subtitles with text blocks longer than
+// MaxTextLinesPerSubtitle cut to the limit. The extra lines are dropped.
+func (s *Service) truncateText(subtitles []srt.Subtitle) []srt.Subtitle {
+	limit := s.config.MaxTextLinesPerSubtitle
+	result := make([]srt.Subtitle, len(subtitles))
+	for i, sub := range subtitles {
+		result[i] = sub
+		if len(sub.Text) > limit {
+			s.logger.Warn().
+				Int("index", sub.Index).
+				Int("lines", len(sub.Text)).
+				Int("limit", limit).
+				Msg("truncated long subtitle text")
+			result[i].Text = sub.Text[:limit:limit]
+		}
+	}
+	return result
+}
+
+// isTimedOutText reports whether translation is the TimedOutText placeholder
+func (s *Service) isTimedOutText(translation []string) bool {
+	return s.config.TimedOutText != "" && len(translation) == 1 && translation[0] == s.config.TimedOutText
+}
+
+// processBatches sends subtitles to the model in batches using prompt,
+// storing each response in Translated. With ContinueOnError, failed
+// batches are kept untranslated, and with TimedOutText, timed out batches
+// get the placeholder; both are reported in a *PartialError.
+func (s *Service) processBatches(ctx context.Context, subtitles []srt.Subtitle, prompt promptFunc) ([]srt.Subtitle, error) {
 	result := make([]srt.Subtitle, 0, len(subtitles))
+	var failed []FailedBatch
 
 	// process in batches
-	for i := 0; i < len(subtitles); i += defaultBatchSize {
-		end := i + defaultBatchSize
-		if end > len(subtitles) {
-			end = len(subtitles)
-		}
+	for i, n := 0, 1; i < len(subtitles); n++ {
+		end := s.batchEnd(subtitles, i)
+		status := "ok"
 
 		batch := subtitles[i:end]
-		translated, err := s.translateBatch(ctx, batch, sourceLang, targetLang)
-		if err != nil {
-			return nil, fmt.Errorf("failed to translate batch %d-%d: %w", i, end, err)
+		batchStart := time.Now()
+		translated, err := s.translateBatch(ctx, batch, i, s.withSourceContext(prompt, subtitles, i, end))
+		s.stats.addBatch(time.Since(batchStart))
+		if err != nil && s.config.TimedOutText != "" && errors.Is(err, ErrRequestTimeout) && ctx.Err() == nil {
+			s.logger.Warn().
+				Err(err).
+				Int("first_index", batch[0].Index).
+				Int("last_index", batch[len(batch)-1].Index).
+				Msg("batch timed out, using placeholder text")
+			status = "timed out"
+			failed = append(failed, FailedBatch{
+				FirstIndex: batch[0].Index,
+				LastIndex:  batch[len(batch)-1].Index,
+				Err:        err,
+			})
+			translated = make([]srt.Subtitle, len(batch))
+			copy(translated, batch)
+			for j := range translated {
+				translated[j].Translated = []string{s.config.TimedOutText}
+			}
+		} else if err != nil {
+			if !s.config.ContinueOnError || ctx.Err() != nil {
+				return nil, err
+			}
+			s.logger.Error().
+				Err(err).
+				Int("first_index", batch[0].Index).
+				Int("last_index", batch[len(batch)-1].Index).
+				Msg("batch failed, keeping the original text")
+			status = "failed"
+			failed = append(failed, FailedBatch{
+				FirstIndex: batch[0].Index,
+				LastIndex:  batch[len(batch)-1].Index,
+				Err:        err,
+			})
+			translated = batch
 		}
 
 		result = append(result, translated...)
@@ -299,7 +782,91 @@ func (s *Service) Translate(ctx context.Context, subtitles []srt.Subtitle, sourc
 			Int("remaining", len(subtitles)-len(result)).
 			Int("percent", int(float64(len(result))/float64(len(subtitles))*100)).
 			Msg("translation progress")
+		progress := BatchProgress{Batch: n, Done: len(result), Total: len(subtitles), Status: status, Subtitles: translated}
+		if s.config.OnBatch != nil {
+			s.config.OnBatch(progress)
+		}
+		if onBatch, ok := ctx.Value(onBatchKey{}).(func(BatchProgress)); ok {
+			onBatch(progress)
+		}
+
+		i = end
 	}
 
+	if len(failed) > 0 {
+		return result, &PartialError{Failed: failed}
+	}
 	return result, nil
 }
+
+// batchEnd returns the exclusive end index of the batch starting at start,
+// ending the batch early rather than letting it exceed MaxTokensPerBatch,
+// end during continuous speech, or span a chapter boundary
+func (s *Service) batchEnd(subtitles []srt.Subtitle, start int) int {
+	end := start + s.config.BatchSize
+	if end > len(subtitles) {
+		end = len(subtitles)
+	}
+
+	if s.config.MaxTokensPerBatch > 0 {
+		tokens := 0
+		for j := start; j < end; j++ {
+			tokens += estimateTokens(strings.Join(subtitles[j].Text, "\n"), s.config.TokenizerModel)
+			if tokens > s.config.MaxTokensPerBatch && j > start {
+				end = j
+				break
+			}
+		}
+	}
+
+	if len(s.config.Silences) > 0 && end < len(subtitles) {
+		end = s.alignToSilence(subtitles, start, end)
+	}
+
+	if len(s.config.Chapters) == 0 {
+		return end
+	}
+
+	batchStart, err := srt.ParseTimestamp(subtitles[start].Start)
+	if err != nil {
+		return end
+	}
+
+	// find the first chapter boundary after the start of the batch
+	idx := sort.Search(len(s.config.Chapters), func(i int) bool {
+		return s.config.Chapters[i] > batchStart
+	})
+	if idx == len(s.config.Chapters) {
+		return end
+	}
+	boundary := s.config.Chapters[idx]
+
+	for j := start + 1; j < end; j++ {
+		if ts, err := srt.ParseTimestamp(subtitles[j].Start); err == nil && ts >= boundary {
+			return j
+		}
+	}
+
+	return end
+}
+
+// alignToSilence moves end back to the nearest subtitle preceded by silence,
+// shrinking the batch by at most half so batches stay reasonably full
+func (s *Service) alignToSilence(subtitles []srt.Subtitle, start, end int) int {
+	for j := end; j > start+(end-start)/2; j-- {
+		prevEnd, err := srt.ParseTimestamp(subtitles[j-1].End)
+		if err != nil {
+			continue
+		}
+		nextStart, err := srt.ParseTimestamp(subtitles[j].Start)
+		if err != nil {
+			continue
+		}
+		for _, silence := range s.config.Silences {
+			if silence.Overlaps(prevEnd, nextStart) {
+				return j
+			}
+		}
+	}
+	return end
+}
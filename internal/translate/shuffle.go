@@ -0,0 +1,96 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// keepMarkersKey is the context key that makes the backends keep the [N]
+// prefix of each response block for the requests made with that context
+type keepMarkersKey struct{}
+
+// markerPattern matches the [N] prefix line of a response block
+var markerPattern = regexp.MustCompile(`^\[(\d+)[^\]]*\]$`)
+
+// stripMarker removes the [N] prefix of a response block unless ctx asks
+// to keep it
+func stripMarker(ctx context.Context, t string) string {
+	if keep, _ := ctx.Value(keepMarkersKey{}).(bool); keep {
+		return t
+	}
+	if idx := strings.Index(t, "]\n"); idx != -1 {
+		t = strings.TrimSpace(t[idx+2:])
+	}
+	return t
+}
+
+// shuffleSubtitles returns subtitles in random order along with the
+// position each one had in the batch
+func shuffleSubtitles(subtitles []srt.Subtitle) ([]srt.Subtitle, []int) {
+	order := rand.Perm(len(subtitles))
+	shuffled := make([]srt.Subtitle, len(subtitles))
+	for i, pos := range order {
+		shuffled[i] = subtitles[pos]
+	}
+	return shuffled, order
+}
+
+// restoreOrder sorts the response blocks of a shuffled batch back by their
+// [N] prefix. It logs whether the model kept the shuffled order or sorted
+// the blocks itself, and returns an error with a diff of the markers when
+// they are not each of 1..N exactly once.
+func (s *Service) restoreOrder(blocks [][]string, order []int) ([][]string, error) {
+	restored := make([][]string, len(order))
+	markers := make([]int, len(blocks))
+	var problems []string
+	for i, lines := range blocks {
+		if m := markerPattern.FindStringSubmatch(strings.TrimSpace(lines[0])); m != nil {
+			markers[i], _ = strconv.Atoi(m[1])
+			lines = lines[1:]
+		}
+		switch n := markers[i]; {
+		case n == 0:
+			problems = append(problems, "block "+strconv.Itoa(i+1)+": no marker")
+		case n > len(order):
+			problems = append(problems, "block "+strconv.Itoa(i+1)+": marker ["+strconv.Itoa(n)+"] out of range")
+		case restored[n-1] != nil:
+			problems = append(problems, "block "+strconv.Itoa(i+1)+": duplicate marker ["+strconv.Itoa(n)+"]")
+		default:
+			restored[n-1] = lines
+		}
+	}
+
+	if len(problems) > 0 {
+		s.logger.Warn().
+			Ints("sent_markers", markerNumbers(order)).
+			Ints("received_markers", markers).
+			Strs("problems", problems).
+			Msg("shuffle validation failed: response markers do not match the sent subtitles")
+		return nil, fmt.Errorf("response markers are not a permutation of 1..%d: %s", len(order), strings.Join(problems, "; "))
+	}
+
+	s.logger.Info().
+		Int("subtitles", len(order)).
+		Bool("kept_shuffled_order", slices.Equal(markers, markerNumbers(order))).
+		Msg("shuffle validation passed: response restored by its markers")
+	return restored, nil
+}
+
+// markerNumbers returns the [N] numbers of a shuffled batch in sent order
+func markerNumbers(order []int) []int {
+	numbers := make([]int, len(order))
+	for i, pos := range order {
+		numbers[i] = pos + 1
+	}
+	return numbers
+}
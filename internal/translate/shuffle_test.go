@@ -0,0 +1,99 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+func TestRestoreOrder(t *testing.T) {
+	s := &Service{logger: zerolog.Nop()}
+	ctx := context.WithValue(context.Background(), keepMarkersKey{}, true)
+	order := []int{2, 0, 1}
+
+	tests := []struct {
+		name     string
+		response string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "shuffled order kept",
+			response: "[3]\nC\n===SUBTITLE===\n[1]\nA\n===SUBTITLE===\n[2]\nB",
+			want:     []string{"A", "B", "C"},
+		},
+		{
+			name:     "sorted by the model",
+			response: "[1]\nA\n===SUBTITLE===\n[2]\nB\n===SUBTITLE===\n[3]\nC",
+			want:     []string{"A", "B", "C"},
+		},
+		{
+			name:     "temperature markers",
+			response: "[3 | temperature 0.2]\nC\n===SUBTITLE===\n[1 | temperature 1.0]\nA\n===SUBTITLE===\n[2]\nB",
+			want:     []string{"A", "B", "C"},
+		},
+		{
+			name:     "duplicate marker",
+			response: "[1]\nA\n===SUBTITLE===\n[1]\nB\n===SUBTITLE===\n[3]\nC",
+			wantErr:  true,
+		},
+		{
+			name:     "missing marker",
+			response: "[1]\nA\n===SUBTITLE===\nB\n===SUBTITLE===\n[3]\nC",
+			wantErr:  true,
+		},
+		{
+			name:     "out of range marker",
+			response: "[1]\nA\n===SUBTITLE===\n[2]\nB\n===SUBTITLE===\n[4]\nC",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.restoreOrder(splitTranslations(ctx, tt.response), order)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("restoreOrder() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("restoreOrder() error = %v", err)
+			}
+			for i, want := range tt.want {
+				if line := strings.Join(got[i], "\n"); line != want {
+					t.Errorf("block %d = %q, want %q", i+1, line, want)
+				}
+			}
+		})
+	}
+}
+
+func TestShuffleRoundTrip(t *testing.T) {
+	s := &Service{logger: zerolog.Nop()}
+	subtitles := []srt.Subtitle{
+		{Text: []string{"one"}},
+		{Text: []string{"two"}},
+		{Text: []string{"three"}},
+		{Text: []string{"four"}},
+	}
+
+	sent, order := shuffleSubtitles(subtitles)
+	ctx := context.WithValue(context.Background(), keepMarkersKey{}, true)
+	got, err := s.restoreOrder(splitTranslations(ctx, s.formatBatch(sent, order)), order)
+	if err != nil {
+		t.Fatalf("restoreOrder() error = %v", err)
+	}
+	for i, sub := range subtitles {
+		if line := strings.Join(got[i], "\n"); line != sub.Text[0] {
+			t.Errorf("block %d = %q, want %q", i+1, line, sub.Text[0])
+		}
+	}
+}
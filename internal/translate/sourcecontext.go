@@ -0,0 +1,62 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// headers of the source context sections around the batch text
+const (
+	sourceContextBefore = "Preceding subtitles, for context only (do not translate or include them in the reply):"
+	sourceContextAfter  = "Following subtitles, for context only (do not translate or include them in the reply):"
+)
+
+// withSourceContext returns prompt with up to SourceContextLines lines of
+// original text from before and after subtitles[start:end] added around
+// the batch text. The context has no [N] markers, so it is not expected
+// back as translations.
+func (s *Service) withSourceContext(prompt promptFunc, subtitles []srt.Subtitle, start, end int) promptFunc {
+	n := s.config.SourceContextLines
+	if n <= 0 {
+		return prompt
+	}
+
+	var before, after []string
+	for i := start - 1; i >= 0 && len(before) < n; i-- {
+		lines := subtitles[i].Text
+		for j := len(lines) - 1; j >= 0 && len(before) < n; j-- {
+			before = append(before, lines[j])
+		}
+	}
+	slices.Reverse(before)
+	for i := end; i < len(subtitles) && len(after) < n; i++ {
+		for _, line := range subtitles[i].Text {
+			if len(after) == n {
+				break
+			}
+			after = append(after, line)
+		}
+	}
+	if len(before) == 0 && len(after) == 0 {
+		return prompt
+	}
+
+	return func(text string, count int) (string, error) {
+		var b strings.Builder
+		if len(before) > 0 {
+			b.WriteString(sourceContextBefore + "\n")
+			b.WriteString(strings.Join(before, "\n") + "\n\n")
+		}
+		b.WriteString(text)
+		if len(after) > 0 {
+			b.WriteString("\n" + sourceContextAfter + "\n")
+			b.WriteString(strings.Join(after, "\n") + "\n")
+		}
+		return prompt(b.String(), count)
+	}
+}
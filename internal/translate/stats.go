@@ -0,0 +1,56 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats counts the batches and retries of a Service
+type Stats struct {
+	// Batches is the number of batches sent, including failed ones
+	Batches int
+	// BatchTime is the total time spent on the batches
+	BatchTime time.Duration
+	// Retries is the number of requests repeated after an error or an
+	// incomplete response
+	Retries int
+}
+
+// AverageBatchTime returns BatchTime divided by Batches, or 0 without batches
+func (s Stats) AverageBatchTime() time.Duration {
+	if s.Batches == 0 {
+		return 0
+	}
+	return s.BatchTime / time.Duration(s.Batches)
+}
+
+// statsCounter collects Stats for concurrent Translate calls
+type statsCounter struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+// addBatch records a finished batch that took elapsed
+func (c *statsCounter) addBatch(elapsed time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Batches++
+	c.stats.BatchTime += elapsed
+}
+
+// addRetry records a repeated request
+func (c *statsCounter) addRetry() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Retries++
+}
+
+// Stats returns the batches and retries counted since the service was created
+func (s *Service) Stats() Stats {
+	s.stats.mu.Lock()
+	defer s.stats.mu.Unlock()
+	return s.stats.stats
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// TranslateStream reads SRT content from r, translates it and writes the
+// translated SRT to w, e.g. for use in a pipe.
+//
+// Subtitles are translated in batches with context from their neighbours,
+// so the whole input is read and translated before anything is written;
+// nothing reaches w until every batch has completed.
+func (s *Service) TranslateStream(ctx context.Context, r io.Reader, w io.Writer, sourceLang, targetLang string) error {
+	subtitles, err := srt.NewParser(false).ParseReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to parse input: %w", err)
+	}
+
+	translated, err := s.Translate(ctx, subtitles, sourceLang, targetLang)
+	if err != nil {
+		return err
+	}
+
+	if err := srt.NewWriter(false).WriteWriter(w, translated); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
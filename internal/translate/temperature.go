@@ -0,0 +1,76 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// word counts at or below which a subtitle gets the short or medium
+// temperature hint; longer subtitles get the long one
+const (
+	shortSubtitleWords  = 2
+	mediumSubtitleWords = 8
+)
+
+// temperature hints given for short, medium and long subtitles
+const (
+	shortTemperature  = 0.2
+	mediumTemperature = 0.7
+	longTemperature   = 1.0
+)
+
+// temperatureRule explains the temperature hints of the subtitle markers
+const temperatureRule = "Each subtitle marker carries a target temperature, e.g. [3 | temperature 0.2]: " +
+	"translate low-temperature subtitles (names, exclamations) exactly and give high-temperature ones natural phrasing. " +
+	"Reply with plain [N] markers"
+
+// temperatureHint returns the target temperature for sub by its length
+func temperatureHint(sub srt.Subtitle) float64 {
+	switch words := subtitleWords(sub.Text); {
+	case words <= shortSubtitleWords:
+		return shortTemperature
+	case words <= mediumSubtitleWords:
+		return mediumTemperature
+	default:
+		return longTemperature
+	}
+}
+
+// subtitleWords counts the words of lines
+func subtitleWords(lines []string) int {
+	return len(strings.Fields(strings.Join(lines, " ")))
+}
+
+// formatMarker returns the [N] marker of the subtitle at position i of a
+// batch, with its temperature hint when TempByLength is set
+func (s *Service) formatMarker(i int, sub srt.Subtitle) string {
+	if !s.config.TempByLength {
+		return fmt.Sprintf("[%d]", i+1)
+	}
+	return fmt.Sprintf("[%d | temperature %.1f]", i+1, temperatureHint(sub))
+}
+
+// logTemperatureCompliance logs how many short subtitles were reproduced
+// verbatim, as a rough check that the model follows the exact
+// translation hint for names and exclamations
+func (s *Service) logTemperatureCompliance(subtitles []srt.Subtitle) {
+	short, verbatim := 0, 0
+	for _, sub := range subtitles {
+		if len(sub.Translated) == 0 || subtitleWords(sub.Text) > shortSubtitleWords {
+			continue
+		}
+		short++
+		if strings.Join(sub.Text, "\n") == strings.Join(sub.Translated, "\n") {
+			verbatim++
+		}
+	}
+	s.logger.Debug().
+		Int("short", short).
+		Int("verbatim", verbatim).
+		Msg("temperature hint compliance")
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// DefaultTokenizerModel is the tiktoken encoding used when none is configured
+const DefaultTokenizerModel = "cl100k_base"
+
+// tokenizerModels are the supported tiktoken encodings. Tokens are counted
+// with tiktoken in builds with the tiktoken tag and estimated from
+// character counts otherwise.
+var tokenizerModels = []string{"cl100k_base", "o200k_base", "p50k_base"}
+
+// validateTokenizerModel returns an error if name is not a supported encoding
+func validateTokenizerModel(name string) error {
+	if !slices.Contains(tokenizerModels, name) {
+		return fmt.Errorf("unsupported tokenizer model %q (want cl100k_base, o200k_base or p50k_base)", name)
+	}
+	return nil
+}
+
+// TokenizerForModel returns the tiktoken encoding used by model,
+// defaulting to DefaultTokenizerModel for unknown models
+func TokenizerForModel(model string) string {
+	// OpenRouter models are prefixed with the provider
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	for _, prefix := range []string{"gpt-4o", "gpt-4.1", "o1", "o3", "o4"} {
+		if strings.HasPrefix(model, prefix) {
+			return "o200k_base"
+		}
+	}
+	return DefaultTokenizerModel
+}
+
+// Usage is an estimate of the tokens used by a translation
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// EstimateUsage estimates the tokens needed to translate subtitles with the
+// built-in prompt in batches of batchSize (0 uses DefaultBatchSize). Output
+// is assumed to be about as long as the batch text.
+func EstimateUsage(subtitles []srt.Subtitle, sourceLang, targetLang string, batchSize int, encoding string) Usage {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var usage Usage
+	for i := 0; i < len(subtitles); i += batchSize {
+		batch := subtitles[i:min(i+batchSize, len(subtitles))]
+
+		var text strings.Builder
+		for j, sub := range batch {
+			if j > 0 {
+				text.WriteString("\n===SUBTITLE===\n")
+			}
+			fmt.Fprintf(&text, "[%d]\n%s\n", j+1, strings.Join(sub.Text, "\n"))
+		}
+
+		usage.InputTokens += estimateTokens(buildPrompt(sourceLang, targetLang, text.String(), EffectsTranslated, blocksFormat), encoding)
+		usage.OutputTokens += estimateTokens(text.String(), encoding)
+	}
+	return usage
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//go:build !tiktoken
+
+package translate
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// tokenizerCharsPerToken is the average number of ASCII characters per token
+// for each supported tiktoken encoding
+var tokenizerCharsPerToken = map[string]float64{
+	"cl100k_base": 4.0,
+	"o200k_base":  4.2,
+	"p50k_base":   3.6,
+}
+
+// estimateTokens estimates the number of tokens in text for the given
+// encoding. Non-ASCII characters are counted as one token each, which is
+// close for CJK text and errs on the high side for accented Latin text.
+func estimateTokens(text, encoding string) int {
+	charsPerToken, ok := tokenizerCharsPerToken[encoding]
+	if !ok {
+		charsPerToken = tokenizerCharsPerToken[DefaultTokenizerModel]
+	}
+
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return int(math.Ceil(float64(ascii)/charsPerToken)) + other
+}
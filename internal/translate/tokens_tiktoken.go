@@ -0,0 +1,54 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//go:build tiktoken
+
+package translate
+
+import (
+	"sync"
+
+	"github.com/pkoukk/tiktoken-go"
+	tiktoken_loader "github.com/pkoukk/tiktoken-go-loader"
+)
+
+func init() {
+	// the encodings are embedded in the binary rather than downloaded
+	tiktoken.SetBpeLoader(tiktoken_loader.NewOfflineLoader())
+}
+
+var (
+	// encodingsMu guards encodings, the tiktoken encodings loaded so far
+	encodingsMu sync.Mutex
+	encodings   = map[string]*tiktoken.Tiktoken{}
+)
+
+// getEncoding returns the tiktoken encoding named name, loading it on
+// first use, or DefaultTokenizerModel for unknown names
+func getEncoding(name string) (*tiktoken.Tiktoken, error) {
+	if validateTokenizerModel(name) != nil {
+		name = DefaultTokenizerModel
+	}
+
+	encodingsMu.Lock()
+	defer encodingsMu.Unlock()
+	if enc, ok := encodings[name]; ok {
+		return enc, nil
+	}
+	enc, err := tiktoken.GetEncoding(name)
+	if err != nil {
+		return nil, err
+	}
+	encodings[name] = enc
+	return enc, nil
+}
+
+// estimateTokens counts the tokens of text with the given tiktoken encoding
+func estimateTokens(text, encoding string) int {
+	enc, err := getEncoding(encoding)
+	if err != nil {
+		// the encodings are embedded, so this is a broken build
+		panic(err)
+	}
+	return len(enc.Encode(text, nil, nil))
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+// toneSampleSize is the number of subtitles sent for tone detection
+const toneSampleSize = 10
+
+// tonePrompt asks the model for the tone of a subtitle sample
+const tonePrompt = `Describe the tone of this content in one word: formal/casual/humorous/dramatic.
+Reply with only that word.
+
+%s`
+
+// defaultToneRules are the prompt rules added for each detected tone
+// unless overridden by ServiceConfig.ToneRules
+var defaultToneRules = map[string]string{
+	"formal":   "Use a formal register and avoid slang",
+	"casual":   "Use a relaxed, conversational register",
+	"humorous": "Keep the humor; adapt jokes and wordplay so they work in the target language",
+	"dramatic": "Keep the dramatic intensity; prefer vivid, emotionally charged wording",
+}
+
+// detectTone asks the model for the tone of the first subtitles
+func (s *Service) detectTone(ctx context.Context, subtitles []srt.Subtitle) (string, error) {
+	sample := subtitles[:min(len(subtitles), toneSampleSize)]
+
+	var text strings.Builder
+	for _, sub := range sample {
+		text.WriteString(strings.Join(sub.Text, "\n"))
+		text.WriteString("\n")
+	}
+
+	response, err := s.send(ctx, fmt.Sprintf(tonePrompt, text.String()), 1, 0)
+	if err != nil {
+		return "", err
+	}
+	if len(response) == 0 || len(response[0]) == 0 {
+		return "", fmt.Errorf("empty tone response")
+	}
+
+	tone := strings.ToLower(strings.TrimFunc(response[0][0], func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}))
+	if _, ok := defaultToneRules[tone]; !ok {
+		return "", fmt.Errorf("unexpected tone %q", response[0][0])
+	}
+	return tone, nil
+}
+
+// toneRule returns the prompt rule for tone, or "" if none was detected
+func (s *Service) toneRule(tone string) string {
+	if tone == "" {
+		return ""
+	}
+	if rule, ok := s.config.ToneRules[tone]; ok {
+		return rule
+	}
+	return defaultToneRules[tone]
+}
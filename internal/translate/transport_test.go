@@ -0,0 +1,107 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package translate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportsDoNotModifyRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport func(base http.RoundTripper) http.RoundTripper
+		header    string
+		fields    []string
+	}{
+		{
+			name: "openrouter",
+			transport: func(base http.RoundTripper) http.RoundTripper {
+				return &openRouterTransport{
+					base:          base,
+					siteURL:       "https://example.com",
+					appName:       "test",
+					providerOrder: []string{"openai"},
+					transforms:    []string{"none"},
+				}
+			},
+			header: "X-Title",
+			fields: []string{"provider", "transforms"},
+		},
+		{
+			name: "lmstudio",
+			transport: func(base http.RoundTripper) http.RoundTripper {
+				return &lmStudioTransport{base: base, keepAlive: 300}
+			},
+			fields: []string{"keep_alive"},
+		},
+	}
+
+	const body = `{"model":"test"}`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent *http.Request
+			var sentBody []byte
+			base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				sent = req
+				var err error
+				sentBody, err = io.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			})
+
+			req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/chat/completions", strings.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			originalBody := req.Body
+
+			if _, err := tt.transport(base).RoundTrip(req); err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+
+			if sent == req {
+				t.Fatal("the caller's request was passed on instead of a clone")
+			}
+			if len(req.Header) != 0 {
+				t.Errorf("caller's headers modified: %v", req.Header)
+			}
+			if req.Body != originalBody || req.ContentLength != int64(len(body)) {
+				t.Errorf("caller's body modified")
+			}
+			if rc, _ := req.GetBody(); rc != nil {
+				if b, _ := io.ReadAll(rc); string(b) != body {
+					t.Errorf("caller's GetBody returns %q, want %q", b, body)
+				}
+			}
+			if tt.header != "" && sent.Header.Get(tt.header) == "" {
+				t.Errorf("sent request has no %s header", tt.header)
+			}
+			var payload map[string]json.RawMessage
+			if err := json.Unmarshal(sentBody, &payload); err != nil {
+				t.Fatalf("sent body %q is not JSON: %v", sentBody, err)
+			}
+			for _, field := range append(tt.fields, "model") {
+				if _, ok := payload[field]; !ok {
+					t.Errorf("sent body %s has no %q field", sentBody, field)
+				}
+			}
+			if sent.ContentLength != int64(len(sentBody)) {
+				t.Errorf("sent ContentLength = %d, body is %d bytes", sent.ContentLength, len(sentBody))
+			}
+		})
+	}
+}
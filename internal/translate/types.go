@@ -3,6 +3,18 @@
 
 package translate
 
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/s0up4200/SRTran/internal/media"
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
 // Backend represents the AI service provider
 type Backend string
 
@@ -11,38 +23,247 @@ const (
 	BackendOpenRouter Backend = "openrouter"
 	BackendGoogleAI   Backend = "googleai"
 	BackendLMStudio   Backend = "lmstudio"
+	BackendVertexAI   Backend = "vertexai"
 )
 
+// defaultVertexAILocation is the Vertex AI region used when none is configured
+const defaultVertexAILocation = "us-central1"
+
 // ServiceConfig holds the configuration for the translation service
 type ServiceConfig struct {
 	APIKey  string
 	BaseURL string
 	Model   string
-	Verbose bool
-	Backend Backend
+	// ModelFallback is an ordered list of models to try when the current
+	// model fails with a quota or authorization error. When set, the first
+	// entry replaces Model.
+	ModelFallback []string
+	Verbose       bool
+	Backend       Backend
 	// RPM is the maximum number of requests per minute
 	// if set to 0, no rate limiting is applied
 	RPM int
+	// BurstSize is the number of requests that may be sent at once
+	// before the RPM limit applies; defaults to RPM
+	BurstSize int
+	// Logger is used for all service logging when set,
+	// otherwise a console logger writing to stdout is created
+	Logger *zerolog.Logger
+	// Organization is sent as the OpenAI-Organization header
+	// for the OpenAI backend
+	Organization string
+	// OpenRouterSiteURL and OpenRouterAppName are sent as the HTTP-Referer
+	// and X-Title headers for OpenRouter attribution
+	OpenRouterSiteURL string
+	OpenRouterAppName string
+	// OpenRouterProviderOrder lists the providers OpenRouter should try
+	// first, e.g. "Anthropic", "AWS Bedrock"
+	OpenRouterProviderOrder []string
+	// OpenRouterTransforms are the prompt transforms OpenRouter applies,
+	// e.g. "middle-out"; empty uses its default and ["none"] disables them
+	OpenRouterTransforms []string
+	// LMStudioKeepAlive asks LM Studio to keep the model loaded for this
+	// many seconds after each request, so it is not unloaded mid-job;
+	// 0 leaves it to LM Studio
+	LMStudioKeepAlive int
+	// ProjectID and Location select the Google Cloud project and region
+	// for the Vertex AI backend
+	ProjectID string
+	Location  string
+	// Chapters are chapter start times; batches never span a chapter boundary
+	Chapters []time.Duration
+	// Silences are silent intervals of the audio; batches end, where
+	// possible, between subtitles separated by silence
+	Silences []media.Interval
+	// MaxCharsPerLine is the length limit of translated lines, handled
+	// according to LineLimitMode; 0 disables the limit
+	MaxCharsPerLine int
+	WrapAlgorithm   srt.WrapAlgorithm
+	// LineLimitMode is LineLimitWarn (the default), LineLimitWrap, which
+	// re-wraps long lines using WrapAlgorithm, or LineLimitTruncate
+	LineLimitMode string
+	// RateLimitStrategy is RateLimitFixed (the default) or
+	// RateLimitAdaptive, which adjusts RPM to the API's rate limit headers
+	RateLimitStrategy string
+	// NCandidates is the number of translations requested per batch from
+	// the OpenAI backend; 0 or 1 requests one
+	NCandidates int
+	// ChooseCandidate picks between differing candidates when NCandidates
+	// is above 1; if nil the first is used and the others are logged
+	ChooseCandidate CandidateChooser
+	// OnBatch is called after each batch is translated, failed or timed out
+	OnBatch func(BatchProgress)
+	// Effects selects whether sound-effect annotations are translated;
+	// empty uses EffectsTranslated
+	Effects EffectsMode
+	// UseJSONSchema requests OpenAI structured outputs matching a JSON
+	// schema instead of parsing ===SUBTITLE=== separators; the model
+	// must support structured outputs
+	UseJSONSchema bool
+	// SafetyThreshold is the Google AI safety filter level applied to all
+	// harm categories; empty uses DefaultSafetyThreshold
+	SafetyThreshold string
+	// Grounding lets Gemini use Google Search to get named entities such
+	// as characters and places right; it is billed per request
+	Grounding bool
+	// FailFast returns the first translation error instead of retrying
+	FailFast bool
+	// RequestTimeout bounds each request to the backend; a request that
+	// times out is retried like other failures. 0 disables the timeout.
+	RequestTimeout time.Duration
+	// TimedOutText is used as the translation of every subtitle in a
+	// batch whose requests timed out, instead of failing the batch;
+	// the batch is still reported in a *PartialError
+	TimedOutText string
+	// ContinueOnError keeps translating after a batch fails. The failed
+	// batch is left untranslated and Translate returns a *PartialError
+	// with the subtitles.
+	ContinueOnError bool
+	// NoRetry makes exactly one attempt per batch and request, without
+	// retries, rate limit backoff or switching to a fallback model
+	NoRetry bool
+	// BatchSize is the number of subtitles sent per request;
+	// 0 uses DefaultBatchSize
+	BatchSize int
+	// MaxTextLinesPerSubtitle truncates the text of longer subtitles, such
+	// as OCR errors spanning many lines, before translating; the extra
+	// lines are discarded. 0 disables the limit.
+	MaxTextLinesPerSubtitle int
+	// MaxTokensPerBatch ends a batch early once the estimated token count
+	// of its subtitle text would exceed this limit; 0 disables the limit
+	MaxTokensPerBatch int
+	// TokenizerModel is the tiktoken encoding used to estimate tokens:
+	// cl100k_base (default), o200k_base or p50k_base
+	TokenizerModel string
+	// RetranslateThreshold retranslates, one at a time and at a higher
+	// temperature, subtitles whose translation is more similar to the
+	// original than this ratio (0-1); 0 disables the check
+	RetranslateThreshold float64
+	// SourceContextLines adds up to this many lines of original text from
+	// before and after each batch to its prompt as context not to translate
+	SourceContextLines int
+	// TempByLength gives each subtitle in the prompt a target temperature
+	// hint by its length: low for names and exclamations, higher for long
+	// lines that benefit from natural phrasing
+	TempByLength bool
+	// ShuffleValidate sends each batch in random order and restores it by
+	// the [N] markers of the response, logging a diff when they do not
+	// match. It is meant for checking models and the response parsing.
+	ShuffleValidate bool
+	// TwoPass sends the translations through a second request that
+	// rephrases them to sound natural in the target language
+	TwoPass bool
+	// TwoPassModel is the model used for the TwoPass requests; empty
+	// uses the current model
+	TwoPassModel string
+	// ToneDetection asks the model for the tone of the first subtitles
+	// and adds the matching rule from ToneRules to the prompt
+	ToneDetection bool
+	// ToneRules overrides the built-in prompt rule for each detected tone
+	ToneRules map[string]string
+	// PromptTemplate is a text/template that replaces the built-in prompt;
+	// see promptData for the fields available to it
+	PromptTemplate string
+	// RewritePrompt is applied to every prompt before it is sent, after
+	// the built-in prompt or PromptTemplate has been rendered
+	RewritePrompt func(sourceLang, targetLang, prompt string) (string, error)
+	// Glossary lists term translations as "source = target" pairs
+	// separated by semicolons. It is added as a rule to the built-in
+	// prompt and made available to prompt templates as {{.Glossary}}.
+	Glossary string
+}
+
+// BatchProgress describes a finished batch
+type BatchProgress struct {
+	// Batch is the number of the batch, counting from 1
+	Batch int
+	// Done and Total count the subtitles sent to the model
+	Done  int
+	Total int
+	// Status is "ok", "failed" or "timed out"
+	Status string
+	// Subtitles are the subtitles of the batch, with their translations
+	// unless the batch failed
+	Subtitles []srt.Subtitle
+}
+
+// onBatchKey is the context key of the callback set by WithOnBatch
+type onBatchKey struct{}
+
+// WithOnBatch returns a context that makes Translate calls made with it
+// report each finished batch to fn as well as to OnBatch. It tells apart
+// the progress of files translated at once by the same Service.
+func WithOnBatch(ctx context.Context, fn func(BatchProgress)) context.Context {
+	return context.WithValue(ctx, onBatchKey{}, fn)
+}
+
+// promptFunc builds the prompt for a batch of count subtitles
+// formatted as text
+type promptFunc func(text string, count int) (string, error)
+
+// promptData holds the values available to a custom prompt template
+type promptData struct {
+	SourceLang    string
+	TargetLang    string
+	BatchText     string
+	SubtitleCount int
+	Glossary      string
+	// Tone is the detected tone when ToneDetection is enabled
+	Tone string
 }
 
 // translationPrompt is the standard prompt template for all translation models
 const translationPrompt = `You are a professional subtitle translator. Translate exactly %s to %s following these rules:
-1. Preserve exact timing by keeping text length similar
-2. Maintain original line breaks and formatting symbols (e.g., <i>, [music])
-3. Never split or merge subtitle blocks
-4. Keep proper nouns/technical terms in original language when no direct translation exists
-5. Use colloquial speech matching the source register
-6. Handle idioms with culturally equivalent expressions
-7. Preserve numbers, measurements, and codes exactly
-8. Maintain capitalization style for on-screen text
-9. Keep placeholder markers like [%%1] unchanged
-10. Use contractions where natural for spoken language
-
-Format:
-[N] (subtitle number)
-Translated text (same line breaks)
-===SUBTITLE=== separator between blocks
+%sFormat:
+%s
 
 Here are the subtitles to translate:
 
 %s`
+
+// baseRules are the numbered rules of translationPrompt. The empty rule
+// 2 is replaced with the rule for the EffectsMode.
+var baseRules = []string{
+	"Preserve exact timing by keeping text length similar",
+	"",
+	"Never split or merge subtitle blocks",
+	"Keep proper nouns/technical terms in original language when no direct translation exists",
+	"Use colloquial speech matching the source register",
+	"Handle idioms with culturally equivalent expressions",
+	"Preserve numbers, measurements, and codes exactly",
+	"Maintain capitalization style for on-screen text",
+	"Keep placeholder markers like [%1] unchanged",
+	"Use contractions where natural for spoken language",
+}
+
+// blocksFormat is the response format of translationPrompt for responses
+// split on ===SUBTITLE=== separators
+const blocksFormat = `[N] (subtitle number)
+Translated text (same line breaks)
+===SUBTITLE=== separator between blocks`
+
+// jsonFormat is the response format of translationPrompt when
+// UseJSONSchema requests structured outputs
+const jsonFormat = `A JSON object {"translations": [{"index": N, "lines": ["..."]}]}
+One entry per subtitle block, N is the subtitle number from its [N] marker
+lines holds the translated text (same line breaks)`
+
+// buildPrompt fills in translationPrompt; all backends must use it so the
+// verbs of the format string are always given in the same order.
+// effects selects rule 2, format is blocksFormat or jsonFormat and
+// extraRules are numbered after baseRules.
+func buildPrompt(sourceLang, targetLang, text string, effects EffectsMode, format string, extraRules ...string) string {
+	effectsRule, ok := effectsRules[effects]
+	if !ok {
+		effectsRule = effectsRules[EffectsTranslated]
+	}
+
+	rules := append(slices.Clone(baseRules), extraRules...)
+	rules[1] = effectsRule
+
+	var numbered strings.Builder
+	for i, rule := range rules {
+		fmt.Fprintf(&numbered, "%d. %s\n", i+1, rule)
+	}
+	return fmt.Sprintf(translationPrompt, sourceLang, targetLang, numbered.String(), format, text)
+}
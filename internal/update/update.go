@@ -0,0 +1,169 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package update
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// latestReleaseURL is the GitHub API endpoint for the latest published release
+const latestReleaseURL = "https://api.github.com/repos/21d5/SRTran/releases/latest"
+
+// Release represents the subset of the GitHub release response we care about
+type Release struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// LatestRelease fetches the latest release from GitHub
+func LatestRelease(ctx context.Context) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", latestReleaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get latest release: %s", string(body))
+	}
+
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+
+	if release.TagName == "" {
+		return nil, fmt.Errorf("latest release has no tag name")
+	}
+
+	return &release, nil
+}
+
+// IsNewer reports whether latest is a newer semantic version than current.
+// Versions that cannot be parsed (such as "dev" builds) are never considered outdated.
+func IsNewer(latest, current string) (bool, error) {
+	l, err := parseSemver(latest)
+	if err != nil {
+		return false, err
+	}
+	c, err := parseSemver(current)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range l.core {
+		if l.core[i] != c.core[i] {
+			return l.core[i] > c.core[i], nil
+		}
+	}
+
+	// a release version is newer than any pre-release of the same core version
+	switch {
+	case l.prerelease == c.prerelease:
+		return false, nil
+	case l.prerelease == "":
+		return true, nil
+	case c.prerelease == "":
+		return false, nil
+	default:
+		return comparePrerelease(l.prerelease, c.prerelease) > 0, nil
+	}
+}
+
+// comparePrerelease compares two pre-release versions like "rc.10" by
+// their dot-separated identifiers as semver orders them: numeric
+// identifiers numerically and below alphanumeric ones, which compare as
+// strings, and a shorter list of otherwise equal identifiers first. It
+// returns -1, 0 or +1.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		aNum, bNum := isNumeric(as[i]), isNumeric(bs[i])
+		switch {
+		case aNum && bNum:
+			// compare by length first so long numbers cannot overflow
+			if c := cmp.Compare(len(as[i]), len(bs[i])); c != 0 {
+				return c
+			}
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// isNumeric reports whether s is a non-empty string of ASCII digits
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+type semver struct {
+	core       [3]int
+	prerelease string
+}
+
+// parseSemver parses versions like "v1.2.3", "1.2" or "v1.2.3-rc.1+build"
+func parseSemver(v string) (semver, error) {
+	var sv semver
+
+	s := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if idx := strings.Index(s, "+"); idx != -1 {
+		s = s[:idx]
+	}
+	if idx := strings.Index(s, "-"); idx != -1 {
+		sv.prerelease = s[idx+1:]
+		s = s[:idx]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return sv, fmt.Errorf("invalid version: %q", v)
+	}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return sv, fmt.Errorf("invalid version: %q", v)
+		}
+		sv.core[i] = n
+	}
+
+	return sv, nil
+}
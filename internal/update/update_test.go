@@ -0,0 +1,36 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package update
+
+import "testing"
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{latest: "v1.2.4", current: "v1.2.3", want: true},
+		{latest: "v1.2.3", current: "v1.2.3", want: false},
+		{latest: "v1.10.0", current: "v1.9.0", want: true},
+		{latest: "v1.2.3", current: "v1.2.3-rc.1", want: true},
+		{latest: "v1.2.3-rc.1", current: "v1.2.3", want: false},
+		{latest: "v1.2.3-rc.10", current: "v1.2.3-rc.2", want: true},
+		{latest: "v1.2.3-rc.2", current: "v1.2.3-rc.10", want: false},
+		{latest: "v1.2.3-rc", current: "v1.2.3-1", want: true},
+		{latest: "v1.2.3-rc.1", current: "v1.2.3-rc", want: true},
+		{latest: "v1.2.3-beta", current: "v1.2.3-alpha", want: true},
+		{latest: "v1.2.3-alpha.beta", current: "v1.2.3-alpha.1", want: true},
+		{latest: "v1.2.3-rc.99999999999999999999", current: "v1.2.3-rc.2", want: true},
+	}
+
+	for _, tt := range tests {
+		got, err := IsNewer(tt.latest, tt.current)
+		if err != nil {
+			t.Fatalf("IsNewer(%q, %q) error = %v", tt.latest, tt.current, err)
+		}
+		if got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
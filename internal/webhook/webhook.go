@@ -0,0 +1,118 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body
+const SignatureHeader = "X-SRTran-Signature"
+
+// maxAttempts is the number of times a webhook is sent before giving up
+const maxAttempts = 3
+
+// timeout bounds each attempt so a slow endpoint cannot hang the CLI
+const timeout = 5 * time.Second
+
+// Payload is the JSON summary posted when a translation completes
+type Payload struct {
+	File      string  `json:"file"`
+	Source    string  `json:"source"`
+	Target    string  `json:"target"`
+	Subtitles int     `json:"subtitles"`
+	ElapsedS  float64 `json:"elapsed_s"`
+	Success   bool    `json:"success"`
+	Error     *string `json:"error"`
+}
+
+// NewPayload creates a payload, recording err if the translation failed
+func NewPayload(file, source, target string, subtitles int, elapsed time.Duration, err error) Payload {
+	payload := Payload{
+		File:      file,
+		Source:    source,
+		Target:    target,
+		Subtitles: subtitles,
+		ElapsedS:  elapsed.Round(time.Second).Seconds(),
+		Success:   err == nil,
+	}
+	if err != nil {
+		msg := err.Error()
+		payload.Error = &msg
+	}
+	return payload
+}
+
+// Send posts the payload to url, retrying on network errors and non-2xx responses.
+// If secret is set the body is signed with HMAC-SHA256 in the X-SRTran-Signature header.
+func Send(ctx context.Context, url, secret string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(time.Duration(attempt) * time.Second)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if lastErr = post(ctx, url, secret, body); lastErr == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("webhook failed after %d attempts: %w", maxAttempts, lastErr)
+}
+
+// post sends a single webhook request
+func post(ctx context.Context, url, secret string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(body, secret))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of body using secret
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package whisper
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// maxUploadSize is the largest file the Whisper API accepts
+const maxUploadSize = 25 << 20
+
+// segmentDuration is the length of the audio segments sent to Whisper.
+// At 48 kbps a segment is about 11 MB, well under maxUploadSize.
+const segmentDuration = 30 * time.Minute
+
+// Transcriber creates SRT subtitles from media files using the OpenAI Whisper API
+type Transcriber struct {
+	client *openai.Client
+}
+
+// NewTranscriber creates a new Whisper transcriber
+func NewTranscriber(apiKey string) (*Transcriber, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("OpenAI API key is required for Whisper transcription")
+	}
+
+	return &Transcriber{
+		client: openai.NewClient(apiKey),
+	}, nil
+}
+
+// Segment is a part of the extracted audio
+type Segment struct {
+	Path string
+	// Offset is where the segment starts in the media file
+	Offset time.Duration
+}
+
+// Audio is the audio track of a media file, split into segments small
+// enough to upload to Whisper
+type Audio struct {
+	dir      string
+	Segments []Segment
+}
+
+// Remove deletes the extracted audio files
+func (a *Audio) Remove() error {
+	return os.RemoveAll(a.dir)
+}
+
+// ExtractAudio extracts the audio track of a media file into compressed
+// mono mp3 segments of segmentDuration using ffmpeg. The caller is
+// responsible for calling Remove on the result.
+func ExtractAudio(ctx context.Context, input string) (*Audio, error) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return nil, fmt.Errorf("ffmpeg not found in PATH: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "srtran-audio-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	audio := &Audio{dir: dir}
+	list := filepath.Join(dir, "segments.csv")
+
+	// mono 16kHz at 48 kbps is about 21.6 MB per hour, so long recordings
+	// are split to stay under the Whisper upload limit. The segment list
+	// records where each segment starts.
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-i", input,
+		"-vn", "-ac", "1", "-ar", "16000", "-b:a", "48k",
+		"-f", "segment",
+		"-segment_time", strconv.Itoa(int(segmentDuration.Seconds())),
+		"-segment_list", list, "-segment_list_type", "csv",
+		"-reset_timestamps", "1",
+		filepath.Join(dir, "audio-%03d.mp3"),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		audio.Remove()
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, string(out))
+	}
+
+	if audio.Segments, err = readSegmentList(list, dir); err != nil {
+		audio.Remove()
+		return nil, err
+	}
+	return audio, nil
+}
+
+// readSegmentList reads the "file,start,end" CSV written by the ffmpeg
+// segment muxer; file names are relative to dir
+func readSegmentList(path, dir string) ([]Segment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read segment list: %w", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse segment list: %w", err)
+	}
+
+	segments := make([]Segment, 0, len(records))
+	for _, record := range records {
+		if len(record) < 2 {
+			return nil, fmt.Errorf("invalid segment list entry: %q", record)
+		}
+		start, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid segment start %q: %w", record[1], err)
+		}
+		segments = append(segments, Segment{
+			Path:   filepath.Join(dir, record[0]),
+			Offset: time.Duration(start * float64(time.Second)),
+		})
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no audio found in input")
+	}
+	return segments, nil
+}
+
+// Transcribe sends each segment of audio to Whisper and returns the
+// transcription in SRT format, with the timestamps of each segment
+// shifted by its offset
+func (t *Transcriber) Transcribe(ctx context.Context, audio *Audio, language string) (string, error) {
+	var subtitles []srt.Subtitle
+	for i, segment := range audio.Segments {
+		info, err := os.Stat(segment.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to stat audio segment: %w", err)
+		}
+		if info.Size() > maxUploadSize {
+			return "", fmt.Errorf("audio segment %d is %d MB, over the %d MB Whisper upload limit",
+				i+1, info.Size()>>20, maxUploadSize>>20)
+		}
+
+		text, err := t.transcribeFile(ctx, segment.Path, language)
+		if err != nil {
+			return "", fmt.Errorf("segment %d: %w", i+1, err)
+		}
+		// a segment without speech has no subtitles
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		parsed, err := srt.NewParser(false).ParseString(text)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse transcription of segment %d: %w", i+1, err)
+		}
+		for _, sub := range parsed {
+			if sub, err = shift(sub, segment.Offset); err != nil {
+				return "", fmt.Errorf("segment %d: %w", i+1, err)
+			}
+			sub.Index = len(subtitles) + 1
+			subtitles = append(subtitles, sub)
+		}
+	}
+	if len(subtitles) == 0 {
+		return "", fmt.Errorf("no speech found in the audio")
+	}
+
+	var out bytes.Buffer
+	if err := srt.NewWriter(false).WriteWriter(&out, subtitles); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// transcribeFile sends one audio file to Whisper and returns the
+// transcription in SRT format
+func (t *Transcriber) transcribeFile(ctx context.Context, audioFile, language string) (string, error) {
+	resp, err := t.client.CreateTranscription(ctx, openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: audioFile,
+		Language: language,
+		Format:   openai.AudioResponseFormatSRT,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to transcribe audio: %w", err)
+	}
+
+	return resp.Text, nil
+}
+
+// shift moves the timestamps of sub later by offset
+func shift(sub srt.Subtitle, offset time.Duration) (srt.Subtitle, error) {
+	start, err := srt.ParseTimestamp(sub.Start)
+	if err != nil {
+		return sub, err
+	}
+	end, err := srt.ParseTimestamp(sub.End)
+	if err != nil {
+		return sub, err
+	}
+	sub.Start = srt.FormatTimestamp(start + offset)
+	sub.End = srt.FormatTimestamp(end + offset)
+	return sub, nil
+}
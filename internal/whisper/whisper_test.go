@@ -0,0 +1,48 @@
+// Copyright (c) 2025, soup and the SRTran contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package whisper
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/s0up4200/SRTran/internal/srt"
+)
+
+func TestReadSegmentList(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "segments.csv")
+	content := "audio-000.mp3,0.000000,1800.024000\naudio-001.mp3,1800.024000,3600.048000\naudio-002.mp3,3600.048000,3725.500000\n"
+	if err := os.WriteFile(list, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readSegmentList(list, dir)
+	if err != nil {
+		t.Fatalf("readSegmentList() error = %v", err)
+	}
+	want := []Segment{
+		{Path: filepath.Join(dir, "audio-000.mp3"), Offset: 0},
+		{Path: filepath.Join(dir, "audio-001.mp3"), Offset: 1800*time.Second + 24*time.Millisecond},
+		{Path: filepath.Join(dir, "audio-002.mp3"), Offset: 3600*time.Second + 48*time.Millisecond},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readSegmentList() = %v, want %v", got, want)
+	}
+}
+
+func TestShift(t *testing.T) {
+	sub := srt.Subtitle{Index: 1, Start: "00:00:01,500", End: "00:00:03,000", Text: []string{"Hello"}}
+
+	got, err := shift(sub, 30*time.Minute+24*time.Millisecond)
+	if err != nil {
+		t.Fatalf("shift() error = %v", err)
+	}
+	if got.Start != "00:30:01,524" || got.End != "00:30:03,024" {
+		t.Errorf("shift() = %s --> %s, want 00:30:01,524 --> 00:30:03,024", got.Start, got.End)
+	}
+}